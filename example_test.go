@@ -7,23 +7,19 @@ import (
 	"github.com/alexkappa/terraform-plugin-helper/helper"
 	"github.com/alexkappa/terraform-plugin-helper/helper/expand"
 	"github.com/alexkappa/terraform-plugin-helper/helper/flatten"
+	"github.com/alexkappa/terraform-plugin-helper/helper/resourcetest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 )
 
-type mountList []*Mount
-
-func (m mountList) Len() int { return len(m) }
-
-func (m mountList) Flatten(i int, d helper.ResourceData) {
-	d.Set("target", m[i].Target)
-	d.Set("source", m[i].Source)
-	d.Set("type", m[i].Type)
+func flattenMount(m *Mount, d helper.ResourceData) {
+	d.Set("target", m.Target)
+	d.Set("source", m.Source)
+	d.Set("type", m.Type)
 }
 
-func ExampleFlatten() {
+func Example_flatten() {
 
-	d := resourceData(nil)
+	d, _ := resourcetest.NewRead(s, nil)
 	api := apiData()
 
 	if spec := api.Spec; spec != nil {
@@ -37,7 +33,7 @@ func ExampleFlatten() {
 
 						if mounts := containerSpec.Mounts; mounts != nil {
 
-							d.Set("mounts", flatten.FlattenList(mountList(mounts)))
+							d.Set("mounts", flatten.SliceFunc(mounts, flattenMount))
 						}
 					}))
 				}
@@ -53,9 +49,9 @@ func ExampleFlatten() {
 	// volume
 }
 
-func ExampleExpand() {
+func Example_expand() {
 
-	d := resourceData(raw)
+	d, _ := resourcetest.NewCreateHCL(&schema.Resource{Schema: s}, config)
 
 	api := &API{}
 	api.Spec = &Spec{}
@@ -87,23 +83,17 @@ func ExampleExpand() {
 	// volume
 }
 
-var raw = map[string]interface{}{
-	"task_spec": []interface{}{
-		map[string]interface{}{
-			"container_spec": []interface{}{
-				map[string]interface{}{
-					"mounts": []interface{}{
-						map[string]interface{}{
-							"target": "/mount/test",
-							"source": "tftest-volume",
-							"type":   "volume",
-						},
-					},
-				},
-			},
-		},
-	},
+const config = `
+task_spec {
+  container_spec {
+    mounts {
+      target = "/mount/test"
+      source = "tftest-volume"
+      type   = "volume"
+    }
+  }
 }
+`
 
 var s = map[string]*schema.Schema{
 	"task_spec": &schema.Schema{
@@ -229,24 +219,6 @@ const apiRaw = `
 }
 `
 
-func resourceData(raw map[string]interface{}) *schema.ResourceData {
-
-	c := terraform.NewResourceConfigRaw(raw)
-
-	sm := schema.InternalMap(s)
-	diff, err := sm.Diff(nil, c, nil, nil, true)
-	if err != nil {
-		panic(err)
-	}
-
-	result, err := sm.Data(nil, diff)
-	if err != nil {
-		panic(err)
-	}
-
-	return result
-}
-
 func apiData() (api *API) {
 	err := json.Unmarshal([]byte(apiRaw), &api)
 	if err != nil {
@@ -0,0 +1,123 @@
+// Package framework adapts the helper packages to resources written with the
+// Terraform Plugin Framework, so that expanders and flatteners can be shared
+// between resources written with the SDK and the framework.
+//
+// The plan and state of a resource are converted to Terraform's internal
+// representation, the same one used by schema.ResourceData, so the expand
+// package reads them the same way.
+//
+//	func (r *serverResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+//		d, diags := framework.NewData(req.State, req.Plan)
+//		resp.Diagnostics.Append(diags...)
+//		if resp.Diagnostics.HasError() {
+//			return
+//		}
+//		if d.HasChange("name") {
+//			server.Name = expand.StringPtr(d, "name")
+//		}
+//		...
+//		resp.Diagnostics.Append(framework.SetState(ctx, &resp.State, flattenServer(server))...)
+//	}
+package framework
+
+import (
+	"context"
+
+	"github.com/alexkappa/terraform-plugin-helper/framework/tfvalue"
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/helper"
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/helper/flatten"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// NewData returns the data of a resource whose values change from its prior
+// state to its plan. The resource is new if its prior state is null.
+//
+// Unknown values of the plan, such as computed attributes, are reported as
+// unset.
+func NewData(state tfsdk.State, plan tfsdk.Plan) (*helper.ChangeData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	prior, err := decode(state.Raw)
+	if err != nil {
+		diags.AddError("Unable to read prior state", err.Error())
+	}
+	next, err := decode(plan.Raw)
+	if err != nil {
+		diags.AddError("Unable to read plan", err.Error())
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+	return helper.NewChangeData(prior, next, state.Raw.IsNull()), nil
+}
+
+// NewStateData returns the data of a resource holding its state, such as
+// during Read. No attribute has changed.
+func NewStateData(state tfsdk.State) (*helper.ChangeData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	v, err := decode(state.Raw)
+	if err != nil {
+		diags.AddError("Unable to read state", err.Error())
+		return nil, diags
+	}
+	return helper.NewChangeData(v, v, false), nil
+}
+
+func decode(v tftypes.Value) (map[string]interface{}, error) {
+	out, err := tfvalue.Decode(v)
+	if err != nil || out == nil {
+		return nil, err
+	}
+	return out.(map[string]interface{}), nil
+}
+
+// Value converts v, held in Terraform's internal representation such as the
+// result of flatten.Flatten, to a value of type typ.
+func Value(ctx context.Context, typ attr.Type, v interface{}) (attr.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	tv, err := tfvalue.Encode(v, typ.TerraformType(ctx))
+	if err != nil {
+		diags.AddError("Unable to convert value", err.Error())
+		return nil, diags
+	}
+	out, err := typ.ValueFromTerraform(ctx, tv)
+	if err != nil {
+		diags.AddError("Unable to convert value", err.Error())
+		return nil, diags
+	}
+	return out, nil
+}
+
+// Flatten flattens f into a value of type typ, which is usually a list or set
+// of objects, or an object.
+//
+//	mounts, diags := framework.Flatten(ctx, types.ListType{ElemType: mountType}, flattenMount(m))
+func Flatten(ctx context.Context, typ attr.Type, f flatten.Flattener) (attr.Value, diag.Diagnostics) {
+	return Value(ctx, typ, flatten.Flatten(f))
+}
+
+// SetState flattens f into state. Attributes which f doesn't set keep their
+// value.
+func SetState(ctx context.Context, state *tfsdk.State, f flatten.Flattener) diag.Diagnostics {
+	var diags diag.Diagnostics
+	v, err := decode(state.Raw)
+	if err != nil {
+		diags.AddError("Unable to read state", err.Error())
+		return diags
+	}
+	d := helper.MapData(v)
+	if d == nil {
+		d = make(helper.MapData)
+	}
+	f.Flatten(d)
+
+	raw, err := tfvalue.Encode(d, state.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		diags.AddError("Unable to write state", err.Error())
+		return diags
+	}
+	state.Raw = raw
+	return nil
+}
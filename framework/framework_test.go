@@ -0,0 +1,213 @@
+package framework
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/helper"
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/helper/expand"
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/helper/flatten"
+	helpertest "github.com/alexkappa/terraform-plugin-helper/sdkv2/helper/testing"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type mount struct {
+	Target string
+	Size   int
+}
+
+type server struct {
+	ID     string
+	Name   string
+	Tags   []string
+	Mounts []mount
+}
+
+func flattenServer(s server) flatten.Flattener {
+	return flatten.FlattenerFunc(func(d helper.ResourceData) {
+		d.Set("id", s.ID)
+		d.Set("name", s.Name)
+		d.Set("tags", s.Tags)
+		d.Set("mount", flatten.SliceFunc(s.Mounts, flattenMount))
+	})
+}
+
+func flattenMount(m mount, d helper.ResourceData) {
+	d.Set("target", m.Target)
+	d.Set("size", m.Size)
+}
+
+var resourceSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"id":      schema.StringAttribute{Computed: true},
+		"name":    schema.StringAttribute{Required: true},
+		"tags":    schema.SetAttribute{ElementType: types.StringType, Optional: true},
+		"enabled": schema.BoolAttribute{Optional: true},
+	},
+	Blocks: map[string]schema.Block{
+		"mount": schema.ListNestedBlock{
+			NestedObject: schema.NestedBlockObject{
+				Attributes: map[string]schema.Attribute{
+					"target": schema.StringAttribute{Required: true},
+					"size":   schema.Int64Attribute{Optional: true},
+				},
+			},
+		},
+	},
+}
+
+func raw(t *testing.T, v map[string]interface{}) tftypes.Value {
+	t.Helper()
+	typ := resourceSchema.Type().TerraformType(context.Background())
+	if v == nil {
+		return tftypes.NewValue(typ, nil)
+	}
+	tv, diags := Value(context.Background(), resourceSchema.Type(), v)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	out, err := tv.ToTerraformValue(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestNewData(t *testing.T) {
+	state := tfsdk.State{Schema: resourceSchema, Raw: raw(t, map[string]interface{}{
+		"id":   "i-123",
+		"name": "foo",
+		"tags": []interface{}{"a", "b"},
+		"mount": []interface{}{
+			map[string]interface{}{"target": "/mnt", "size": 10},
+		},
+	})}
+	plan := tfsdk.Plan{Schema: resourceSchema, Raw: raw(t, map[string]interface{}{
+		"id":   "i-123",
+		"name": "bar",
+		"tags": []interface{}{"b", "a"},
+		"mount": []interface{}{
+			map[string]interface{}{"target": "/mnt", "size": 10},
+		},
+	})}
+
+	d, diags := NewData(state, plan)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	helpertest.Expect(t, d.IsNewResource(), false)
+	helpertest.Expect(t, d.HasChange("name"), true)
+	helpertest.Expect(t, d.HasChange("tags"), false)
+	helpertest.Expect(t, d.HasChange("mount"), false)
+	helpertest.Expect(t, expand.String(d, "name"), "bar")
+	helpertest.Expect(t, expand.Int(d, "mount.0.size"), 0)
+
+	full := expand.WithMode(d, expand.Full)
+	helpertest.Expect(t, expand.Int(full, "mount.0.size"), 10)
+	helpertest.Expect(t, len(expand.Set(full, "tags").List()), 2)
+
+	var m []mount
+	expand.List(full, "mount").Elem(func(d helper.ResourceData) {
+		m = append(m, mount{Target: expand.String(d, "target"), Size: expand.Int(d, "size")})
+	})
+	helpertest.Expect(t, m, []mount{{"/mnt", 10}})
+}
+
+func TestNewDataZero(t *testing.T) {
+	plan := tfsdk.Plan{Schema: resourceSchema, Raw: raw(t, map[string]interface{}{
+		"name":    "foo",
+		"enabled": false,
+	})}
+
+	d, diags := NewData(tfsdk.State{Schema: resourceSchema, Raw: raw(t, nil)}, plan)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	enabled := expand.BoolPtr(d, "enabled")
+	if enabled == nil || *enabled {
+		t.Errorf("Expected enabled to be false, got %v", enabled)
+	}
+	if id := expand.StringPtr(d, "id"); id != nil {
+		t.Errorf("Expected id to be unset, got %q", *id)
+	}
+}
+
+func TestNewDataCreate(t *testing.T) {
+	plan := raw(t, map[string]interface{}{"name": "foo"})
+	typ := resourceSchema.Type().TerraformType(context.Background()).(tftypes.Object)
+	plan, err := tftypes.Transform(plan, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if p.Equal(tftypes.NewAttributePath().WithAttributeName("id")) {
+			return tftypes.NewValue(typ.AttributeTypes["id"], tftypes.UnknownValue), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, diags := NewData(tfsdk.State{Schema: resourceSchema, Raw: raw(t, nil)}, tfsdk.Plan{Schema: resourceSchema, Raw: plan})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	helpertest.Expect(t, d.IsNewResource(), true)
+	helpertest.Expect(t, d.HasChange("name"), true)
+
+	_, ok := d.GetOk("id")
+	helpertest.Expect(t, ok, false)
+}
+
+func TestSetState(t *testing.T) {
+	ctx := context.Background()
+	state := tfsdk.State{Schema: resourceSchema, Raw: raw(t, map[string]interface{}{
+		"name": "foo",
+		"tags": []interface{}{"a"},
+	})}
+
+	diags := SetState(ctx, &state, flatten.FlattenerFunc(func(d helper.ResourceData) {
+		d.Set("id", "i-123")
+		d.Set("mount", flatten.SliceFunc([]mount{{"/mnt", 10}}, flattenMount))
+	}))
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	var name, id types.String
+	state.GetAttribute(ctx, path.Root("name"), &name)
+	state.GetAttribute(ctx, path.Root("id"), &id)
+	helpertest.Expect(t, name.ValueString(), "foo")
+	helpertest.Expect(t, id.ValueString(), "i-123")
+
+	var size types.Int64
+	state.GetAttribute(ctx, path.Root("mount").AtListIndex(0).AtName("size"), &size)
+	helpertest.Expect(t, size.ValueInt64(), int64(10))
+
+	diags = SetState(ctx, &state, flatten.FlattenerFunc(func(d helper.ResourceData) {
+		d.Set("name", 42)
+	}))
+	helpertest.Expect(t, diags.HasError(), true)
+}
+
+func TestFlatten(t *testing.T) {
+	ctx := context.Background()
+	s := server{ID: "i-123", Name: "foo", Tags: []string{"a"}, Mounts: []mount{{"/mnt", 10}}}
+
+	v, diags := Flatten(ctx, types.ListType{ElemType: resourceSchema.Type()}, flattenServer(s))
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	l := v.(types.List)
+	helpertest.Expect(t, len(l.Elements()), 1)
+
+	obj := l.Elements()[0].(types.Object)
+	helpertest.Expect(t, obj.Attributes()["name"], attr.Value(types.StringValue("foo")))
+
+	tf, _ := obj.ToTerraformValue(ctx)
+	size, _, _ := tftypes.WalkAttributePath(tf, tftypes.NewAttributePath().WithAttributeName("mount").WithElementKeyInt(0).WithAttributeName("size"))
+	helpertest.Expect(t, size.(tftypes.Value).Equal(tftypes.NewValue(tftypes.Number, big.NewFloat(10))), true)
+}
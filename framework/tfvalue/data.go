@@ -0,0 +1,215 @@
+package tfvalue
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/helper"
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/helper/flatten"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Data is a helper.ResourceData reading from the prior state and the proposed
+// new state of a resource, as received by providers written directly against
+// terraform-plugin-go.
+//
+// Keys are dotted paths, the same way as they are with schema.ResourceData,
+// which are resolved by navigating the objects, lists, sets and maps of the
+// values. Elements of sets are selected by the hash code of their decoded
+// value, as computed by helper.HashValue.
+type Data struct {
+	prior    tftypes.Value
+	proposed tftypes.Value
+}
+
+// NewData returns the data of a resource whose values change from prior to
+// proposed. The resource is new if prior is null.
+func NewData(prior, proposed tftypes.Value) *Data {
+	return &Data{prior, proposed}
+}
+
+// Value returns the proposed value, including the values set using Set.
+func (d *Data) Value() tftypes.Value {
+	return d.proposed
+}
+
+// IsNewResource reports whether the prior value is null.
+func (d *Data) IsNewResource() bool {
+	return d.prior.Type() == nil || d.prior.IsNull()
+}
+
+// HasChange reports whether the prior and proposed values of key differ.
+func (d *Data) HasChange(key string) bool {
+	o, n := d.GetChange(key)
+	return !helper.Equal(o, n)
+}
+
+// GetChange returns the prior and proposed value for a given key.
+func (d *Data) GetChange(key string) (interface{}, interface{}) {
+	o, _ := lookup(d.prior, key)
+	n, _ := lookup(d.proposed, key)
+	return o, n
+}
+
+// Get returns the proposed value for the given key, or nil if the key doesn't
+// exist or its value is null or unknown.
+func (d *Data) Get(key string) interface{} {
+	v, _ := lookup(d.proposed, key)
+	return v
+}
+
+// GetOk returns the proposed value for the given key and whether or not it is
+// known and not null.
+func (d *Data) GetOk(key string) (interface{}, bool) {
+	return lookup(d.proposed, key)
+}
+
+// GetOkExists returns the proposed value for a given key and whether or not it
+// is known and not null. Unlike schema.ResourceData, zero values such as false
+// are reported as existing, as they are distinguished from null.
+func (d *Data) GetOkExists(key string) (interface{}, bool) {
+	return lookup(d.proposed, key)
+}
+
+// Set replaces the proposed value of key with v, converted to the type of the
+// attribute or element key refers to.
+func (d *Data) Set(key string, v interface{}) error {
+	p, target, err := walk(d.proposed, key)
+	if err != nil {
+		return fmt.Errorf("tfvalue: unable to set %q: %s", key, err)
+	}
+	value, err := Encode(v, target.Type())
+	if err != nil {
+		return fmt.Errorf("tfvalue: unable to set %q: %s", key, err)
+	}
+	proposed, err := tftypes.Transform(d.proposed, func(ap *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if ap.Equal(p) {
+			return value, nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		return fmt.Errorf("tfvalue: unable to set %q: %s", key, err)
+	}
+	d.proposed = proposed
+	return nil
+}
+
+var _ helper.ResourceData = (*Data)(nil)
+
+// lookup resolves key within v and decodes the value it refers to. The "#" and
+// "%" keys hold the number of elements of a list, set or map.
+func lookup(v tftypes.Value, key string) (interface{}, bool) {
+	parts := strings.Split(key, ".")
+	last := parts[len(parts)-1]
+	if last == "#" || last == "%" {
+		_, v, err := walk(v, strings.Join(parts[:len(parts)-1], "."))
+		if err != nil || v.IsNull() || !v.IsKnown() {
+			return nil, false
+		}
+		n, ok := count(v, last)
+		return n, ok
+	}
+	_, v, err := walk(v, key)
+	if err != nil {
+		return nil, false
+	}
+	out, err := Decode(v)
+	if err != nil || out == nil {
+		return nil, false
+	}
+	return out, true
+}
+
+func count(v tftypes.Value, part string) (int, bool) {
+	switch v.Type().(type) {
+	case tftypes.List, tftypes.Set, tftypes.Tuple:
+		if part != "#" {
+			return 0, false
+		}
+		var l []tftypes.Value
+		err := v.As(&l)
+		return len(l), err == nil
+	case tftypes.Map, tftypes.Object:
+		if part != "%" {
+			return 0, false
+		}
+		var m map[string]tftypes.Value
+		err := v.As(&m)
+		return len(m), err == nil
+	}
+	return 0, false
+}
+
+// walk resolves key within v, returning the path and the value it refers to.
+// An empty key refers to v itself.
+func walk(v tftypes.Value, key string) (*tftypes.AttributePath, tftypes.Value, error) {
+	p := tftypes.NewAttributePath()
+	if key == "" {
+		return p, v, nil
+	}
+	for _, part := range strings.Split(key, ".") {
+		if v.Type() == nil || v.IsNull() || !v.IsKnown() {
+			return nil, tftypes.Value{}, fmt.Errorf("%q is null or unknown", part)
+		}
+		step, err := stepOf(v, part)
+		if err != nil {
+			return nil, tftypes.Value{}, err
+		}
+		next, err := v.ApplyTerraform5AttributePathStep(step)
+		if err != nil {
+			return nil, tftypes.Value{}, err
+		}
+		v = next.(tftypes.Value)
+		p = tftypes.NewAttributePathWithSteps(append(p.Steps(), step))
+	}
+	return p, v, nil
+}
+
+func stepOf(v tftypes.Value, part string) (tftypes.AttributePathStep, error) {
+	switch v.Type().(type) {
+	case tftypes.Object:
+		return tftypes.AttributeName(part), nil
+	case tftypes.Map:
+		return tftypes.ElementKeyString(part), nil
+	case tftypes.List, tftypes.Tuple:
+		i, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index %q", part)
+		}
+		return tftypes.ElementKeyInt(i), nil
+	case tftypes.Set:
+		var l []tftypes.Value
+		if err := v.As(&l); err != nil {
+			return nil, err
+		}
+		for _, e := range l {
+			item, err := Decode(e)
+			if err != nil {
+				return nil, err
+			}
+			if strconv.Itoa(hashCode(item)) == part {
+				return tftypes.ElementKeyValue(e), nil
+			}
+		}
+		return nil, fmt.Errorf("no element with hash code %q", part)
+	}
+	return nil, fmt.Errorf("can't select %q of %s", part, v.Type())
+}
+
+func hashCode(v interface{}) int {
+	code := helper.HashValue(v)
+	if code < 0 {
+		code = -code
+	}
+	return code
+}
+
+// Flatten flattens f into a value of type typ, which must be an object type.
+// Attributes f doesn't set are null.
+func Flatten(typ tftypes.Type, f flatten.Flattener) (tftypes.Value, error) {
+	d := make(helper.MapData)
+	f.Flatten(d)
+	return Encode(d, typ)
+}
@@ -0,0 +1,117 @@
+package tfvalue
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/helper"
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/helper/expand"
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/helper/flatten"
+	helpertest "github.com/alexkappa/terraform-plugin-helper/sdkv2/helper/testing"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func value(t *testing.T, v map[string]interface{}) tftypes.Value {
+	t.Helper()
+	out, err := Encode(v, typ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestData(t *testing.T) {
+	prior := value(t, map[string]interface{}{
+		"name":   "foo",
+		"tags":   []interface{}{"a", "b"},
+		"labels": map[string]interface{}{"env": "prod"},
+		"mounts": []interface{}{
+			map[string]interface{}{"target": "/mnt", "size": 10},
+		},
+	})
+	proposed := value(t, map[string]interface{}{
+		"name":    "bar",
+		"enabled": false,
+		"tags":    []interface{}{"b", "a"},
+		"labels":  map[string]interface{}{"env": "dev"},
+		"mounts": []interface{}{
+			map[string]interface{}{"target": "/mnt", "size": 20},
+		},
+	})
+
+	d := NewData(prior, proposed)
+	helpertest.Expect(t, d.IsNewResource(), false)
+	helpertest.Expect(t, d.HasChange("name"), true)
+	helpertest.Expect(t, d.HasChange("tags"), false)
+	helpertest.Expect(t, d.HasChange("labels.env"), true)
+	helpertest.Expect(t, d.HasChange("mounts.0.target"), false)
+	helpertest.Expect(t, d.HasChange("mounts.0.size"), true)
+
+	helpertest.Expect(t, d.Get("mounts.#"), 1)
+	helpertest.Expect(t, d.Get("labels.%"), 1)
+	helpertest.Expect(t, d.Get("tags."+strconv.Itoa(hashCode("a"))), "a")
+	helpertest.Expect(t, d.Get("mounts.1"), nil)
+
+	_, ok := d.GetOk("ratio")
+	helpertest.Expect(t, ok, false)
+	v, ok := d.GetOkExists("enabled")
+	helpertest.Expect(t, v, false)
+	helpertest.Expect(t, ok, true)
+
+	helpertest.Expect(t, expand.String(d, "name"), "bar")
+	helpertest.Expect(t, expand.Int(d, "mounts.0.size"), 20)
+	helpertest.Expect(t, len(expand.Set(expand.WithMode(d, expand.Full), "tags").List()), 2)
+
+	helpertest.Expect(t, NewData(tftypes.NewValue(typ, nil), proposed).IsNewResource(), true)
+}
+
+func TestDataSet(t *testing.T) {
+	d := NewData(tftypes.NewValue(typ, nil), value(t, map[string]interface{}{
+		"name": "foo",
+		"mounts": []interface{}{
+			map[string]interface{}{"target": "/mnt"},
+		},
+	}))
+
+	helpertest.Expect(t, d.Set("id", "i-123"), nil)
+	helpertest.Expect(t, d.Set("mounts.0.size", 10), nil)
+	helpertest.Expect(t, d.Set("tags", []interface{}{"a"}), nil)
+	helpertest.Expect(t, d.Get("id"), "i-123")
+	helpertest.Expect(t, d.Get("mounts.0.size"), 10)
+	helpertest.Expect(t, d.Get("tags.#"), 1)
+
+	size, _, err := tftypes.WalkAttributePath(d.Value(), tftypes.NewAttributePath().WithAttributeName("mounts").WithElementKeyInt(0).WithAttributeName("size"))
+	helpertest.Expect(t, err, nil)
+	helpertest.Expect(t, size.(tftypes.Value).Equal(tftypes.NewValue(tftypes.Number, big.NewFloat(10))), true)
+
+	if err := d.Set("name", 42); err == nil {
+		t.Error("Expected an error setting a number to a string attribute")
+	}
+	if err := d.Set("mounts.3.size", 1); err == nil {
+		t.Error("Expected an error setting an element out of range")
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	v, err := Flatten(typ, flatten.FlattenerFunc(func(d helper.ResourceData) {
+		d.Set("name", "foo")
+		d.Set("mounts", flatten.Func(func(d helper.ResourceData) {
+			d.Set("target", "/mnt")
+		}))
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewData(v, v)
+	helpertest.Expect(t, d.Get("name"), "foo")
+	helpertest.Expect(t, d.Get("mounts.0.target"), "/mnt")
+	helpertest.Expect(t, d.Get("mounts.0.size"), nil)
+	helpertest.Expect(t, d.Get("enabled"), nil)
+
+	_, err = Flatten(typ, flatten.FlattenerFunc(func(d helper.ResourceData) {
+		d.Set("name", true)
+	}))
+	helpertest.Expect(t, err.Error(), `cannot convert bool to tftypes.String at "name"`)
+}
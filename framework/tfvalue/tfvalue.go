@@ -0,0 +1,298 @@
+// Package tfvalue converts between tftypes.Value and Terraform's internal
+// representation of values, made of nested maps, lists and sets, used by
+// schema.ResourceData and the helper packages.
+//
+// It lets providers written directly against terraform-plugin-go use the
+// expand and flatten packages at the protocol level.
+//
+//	d := tfvalue.NewData(prior, proposed)
+//	if d.HasChange("name") {
+//		server.Name = expand.StringPtr(d, "name")
+//	}
+//	...
+//	state, err := tfvalue.Flatten(resourceType, flattenServer(server))
+package tfvalue
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/helper"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Decode converts v to Terraform's internal representation. Objects and maps
+// are converted to a map[string]interface{}, lists and tuples to a
+// []interface{} and sets to a *schema.Set hashed by helper.HashValue.
+//
+// Null and unknown values are converted to nil, and are omitted from the
+// objects holding them, the same way as unset attributes of a
+// schema.ResourceData.
+func Decode(v tftypes.Value) (interface{}, error) {
+	return decode(nil, v)
+}
+
+func decode(p []string, v tftypes.Value) (interface{}, error) {
+	if v.Type() == nil || v.IsNull() || !v.IsKnown() {
+		return nil, nil
+	}
+	switch typ := v.Type().(type) {
+	case tftypes.Object, tftypes.Map:
+		var m map[string]tftypes.Value
+		if err := v.As(&m); err != nil {
+			return nil, wrap(p, err)
+		}
+		out := make(map[string]interface{}, len(m))
+		for k, e := range m {
+			item, err := decode(append(p, k), e)
+			if err != nil {
+				return nil, err
+			}
+			if item != nil {
+				out[k] = item
+			}
+		}
+		return out, nil
+	case tftypes.List, tftypes.Set, tftypes.Tuple:
+		var l []tftypes.Value
+		if err := v.As(&l); err != nil {
+			return nil, wrap(p, err)
+		}
+		out := make([]interface{}, len(l))
+		for i, e := range l {
+			item, err := decode(append(p, strconv.Itoa(i)), e)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = item
+		}
+		if _, ok := typ.(tftypes.Set); ok {
+			return schema.NewSet(helper.HashValue, out), nil
+		}
+		return out, nil
+	default:
+		switch {
+		case typ.Equal(tftypes.String):
+			var s string
+			return s, wrap(p, v.As(&s))
+		case typ.Equal(tftypes.Bool):
+			var b bool
+			return b, wrap(p, v.As(&b))
+		case typ.Equal(tftypes.Number):
+			f := new(big.Float)
+			if err := v.As(&f); err != nil {
+				return nil, wrap(p, err)
+			}
+			return number(f), nil
+		}
+		return nil, fmt.Errorf("unsupported type %s at %q", typ, strings.Join(p, "."))
+	}
+}
+
+// number converts f to an int if it is a whole number in its range, or a
+// float64 otherwise.
+func number(f *big.Float) interface{} {
+	if f.IsInt() {
+		if i, acc := f.Int64(); acc == big.Exact && i >= math.MinInt && i <= math.MaxInt {
+			return int(i)
+		}
+	}
+	v, _ := f.Float64()
+	return v
+}
+
+// Encode converts v, held in Terraform's internal representation, to a value
+// of type typ. A nil v is converted to a null value.
+//
+// Nested blocks, which are held by a list of a single map, may be converted to
+// an object.
+func Encode(v interface{}, typ tftypes.Type) (tftypes.Value, error) {
+	return encode(nil, v, typ)
+}
+
+func encode(p []string, v interface{}, typ tftypes.Type) (tftypes.Value, error) {
+	v = indirect(v)
+	if v == nil {
+		return tftypes.NewValue(typ, nil), nil
+	}
+	switch typ := typ.(type) {
+	case tftypes.Object:
+		if l, ok := v.([]interface{}); ok && len(l) <= 1 {
+			if len(l) == 0 {
+				return tftypes.NewValue(typ, nil), nil
+			}
+			v = indirect(l[0])
+		}
+		m, ok := mapOf(v)
+		if !ok {
+			return tftypes.Value{}, typeError(p, v, typ)
+		}
+		out := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+		for k, t := range typ.AttributeTypes {
+			e, err := encode(append(p, k), m[k], t)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			out[k] = e
+		}
+		var unexpected []string
+		for k := range m {
+			if _, ok := typ.AttributeTypes[k]; !ok {
+				unexpected = append(unexpected, k)
+			}
+		}
+		if len(unexpected) > 0 {
+			sort.Strings(unexpected)
+			return tftypes.Value{}, fmt.Errorf("unexpected attribute %q", strings.Join(append(p, unexpected[0]), "."))
+		}
+		return tftypes.NewValue(typ, out), nil
+	case tftypes.Map:
+		m, ok := mapOf(v)
+		if !ok {
+			return tftypes.Value{}, typeError(p, v, typ)
+		}
+		out := make(map[string]tftypes.Value, len(m))
+		for k, item := range m {
+			e, err := encode(append(p, k), item, typ.ElementType)
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			out[k] = e
+		}
+		return tftypes.NewValue(typ, out), nil
+	case tftypes.List, tftypes.Set, tftypes.Tuple:
+		l, ok := listOf(v)
+		if !ok {
+			return tftypes.Value{}, typeError(p, v, typ)
+		}
+		if t, ok := typ.(tftypes.Tuple); ok && len(t.ElementTypes) != len(l) {
+			return tftypes.Value{}, typeError(p, v, typ)
+		}
+		out := make([]tftypes.Value, len(l))
+		for i, item := range l {
+			e, err := encode(append(p, strconv.Itoa(i)), item, elementType(typ, i))
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+			out[i] = e
+		}
+		return tftypes.NewValue(typ, out), nil
+	default:
+		switch {
+		case typ.Equal(tftypes.String):
+			if s, ok := v.(string); ok {
+				return tftypes.NewValue(typ, s), nil
+			}
+		case typ.Equal(tftypes.Bool):
+			if b, ok := v.(bool); ok {
+				return tftypes.NewValue(typ, b), nil
+			}
+		case typ.Equal(tftypes.Number):
+			if f, ok := bigFloat(v); ok {
+				return tftypes.NewValue(typ, f), nil
+			}
+		default:
+			return tftypes.Value{}, fmt.Errorf("unsupported type %s at %q", typ, strings.Join(p, "."))
+		}
+		return tftypes.Value{}, typeError(p, v, typ)
+	}
+}
+
+func indirect(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		if _, ok := v.(*big.Float); ok {
+			return v
+		}
+		if _, ok := v.(*schema.Set); ok {
+			return v
+		}
+		rv = rv.Elem()
+		v = rv.Interface()
+	}
+	return v
+}
+
+func mapOf(v interface{}) (map[string]interface{}, bool) {
+	switch v := v.(type) {
+	case helper.MapData:
+		return v, true
+	case map[string]interface{}:
+		return v, true
+	case map[string]string:
+		out := make(map[string]interface{}, len(v))
+		for k, s := range v {
+			out[k] = s
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+func listOf(v interface{}) ([]interface{}, bool) {
+	switch v := v.(type) {
+	case *schema.Set:
+		return v.List(), true
+	case []interface{}:
+		return v, true
+	case []string:
+		out := make([]interface{}, len(v))
+		for i, s := range v {
+			out[i] = s
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+func elementType(typ tftypes.Type, i int) tftypes.Type {
+	switch typ := typ.(type) {
+	case tftypes.List:
+		return typ.ElementType
+	case tftypes.Set:
+		return typ.ElementType
+	case tftypes.Tuple:
+		return typ.ElementTypes[i]
+	}
+	return nil
+}
+
+func bigFloat(v interface{}) (*big.Float, bool) {
+	if f, ok := v.(*big.Float); ok {
+		return f, true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Float).SetUint64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, false
+		}
+		return big.NewFloat(f), true
+	}
+	return nil, false
+}
+
+func typeError(p []string, v interface{}, typ tftypes.Type) error {
+	return fmt.Errorf("cannot convert %T to %s at %q", v, typ, strings.Join(p, "."))
+}
+
+func wrap(p []string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("unable to decode %q: %s", strings.Join(p, "."), err)
+}
@@ -0,0 +1,126 @@
+package tfvalue
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/helper"
+	helpertest "github.com/alexkappa/terraform-plugin-helper/sdkv2/helper/testing"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var mountType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"target": tftypes.String,
+	"size":   tftypes.Number,
+}}
+
+var typ = tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+	"name":    tftypes.String,
+	"enabled": tftypes.Bool,
+	"ratio":   tftypes.Number,
+	"tags":    tftypes.Set{ElementType: tftypes.String},
+	"labels":  tftypes.Map{ElementType: tftypes.String},
+	"mounts":  tftypes.List{ElementType: mountType},
+	"id":      tftypes.String,
+}}
+
+func TestDecode(t *testing.T) {
+	v := tftypes.NewValue(typ, map[string]tftypes.Value{
+		"name":    tftypes.NewValue(tftypes.String, "foo"),
+		"enabled": tftypes.NewValue(tftypes.Bool, true),
+		"ratio":   tftypes.NewValue(tftypes.Number, big.NewFloat(1.5)),
+		"tags": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "a"),
+			tftypes.NewValue(tftypes.String, "b"),
+		}),
+		"labels": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"env": tftypes.NewValue(tftypes.String, "prod"),
+		}),
+		"mounts": tftypes.NewValue(tftypes.List{ElementType: mountType}, []tftypes.Value{
+			tftypes.NewValue(mountType, map[string]tftypes.Value{
+				"target": tftypes.NewValue(tftypes.String, "/mnt"),
+				"size":   tftypes.NewValue(tftypes.Number, big.NewFloat(10)),
+			}),
+		}),
+		"id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+
+	got, err := Decode(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	helpertest.Expect(t, got, map[string]interface{}{
+		"name":    "foo",
+		"enabled": true,
+		"ratio":   1.5,
+		"tags":    schema.NewSet(helper.HashValue, []interface{}{"a", "b"}),
+		"labels":  map[string]interface{}{"env": "prod"},
+		"mounts": []interface{}{
+			map[string]interface{}{"target": "/mnt", "size": 10},
+		},
+	})
+
+	got, err = Decode(tftypes.NewValue(typ, nil))
+	helpertest.Expect(t, got, nil)
+	helpertest.Expect(t, err, nil)
+}
+
+func TestEncode(t *testing.T) {
+	in := map[string]interface{}{
+		"name":   "foo",
+		"ratio":  1.5,
+		"tags":   schema.NewSet(schema.HashString, []interface{}{"a"}),
+		"labels": map[string]string{"env": "prod"},
+		"mounts": []interface{}{
+			map[string]interface{}{"target": "/mnt", "size": int64(10)},
+		},
+	}
+	v, err := Encode(in, typ)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := tftypes.NewValue(typ, map[string]tftypes.Value{
+		"name":    tftypes.NewValue(tftypes.String, "foo"),
+		"enabled": tftypes.NewValue(tftypes.Bool, nil),
+		"ratio":   tftypes.NewValue(tftypes.Number, big.NewFloat(1.5)),
+		"tags": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "a"),
+		}),
+		"labels": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"env": tftypes.NewValue(tftypes.String, "prod"),
+		}),
+		"mounts": tftypes.NewValue(tftypes.List{ElementType: mountType}, []tftypes.Value{
+			tftypes.NewValue(mountType, map[string]tftypes.Value{
+				"target": tftypes.NewValue(tftypes.String, "/mnt"),
+				"size":   tftypes.NewValue(tftypes.Number, big.NewFloat(10)),
+			}),
+		}),
+		"id": tftypes.NewValue(tftypes.String, nil),
+	})
+	if !v.Equal(want) {
+		t.Errorf("Expected %s to equal %s", v, want)
+	}
+
+	// nested blocks held by a list may be encoded as an object
+	v, err = Encode([]interface{}{map[string]interface{}{"target": "/mnt"}}, mountType)
+	helpertest.Expect(t, err, nil)
+	helpertest.Expect(t, v.IsNull(), false)
+
+	for _, test := range []struct {
+		v   interface{}
+		err string
+	}{
+		{map[string]interface{}{"name": 1}, `cannot convert int to tftypes.String at "name"`},
+		{map[string]interface{}{"mounts": []interface{}{map[string]interface{}{"size": "big"}}}, `cannot convert string to tftypes.Number at "mounts.0.size"`},
+		{map[string]interface{}{"foo": "bar"}, `unexpected attribute "foo"`},
+	} {
+		_, err := Encode(test.v, typ)
+		if err == nil {
+			t.Errorf("Expected an error encoding %v", test.v)
+			continue
+		}
+		helpertest.Expect(t, err.Error(), test.err)
+	}
+}
@@ -0,0 +1,72 @@
+package helper
+
+// ChangeData is a ResourceData holding an old and a new map, emulating the
+// changes schema.ResourceData reports between a resource's state and its
+// configuration. It can be used to test code reading values only when they
+// have changed, without building a schema.ResourceData.
+//
+// Keys may be dotted paths into nested maps, lists and sets, the same way as
+// they are with schema.ResourceData.
+type ChangeData struct {
+	prior MapData
+	next  MapData
+	isNew bool
+}
+
+// NewChangeData returns a ChangeData whose values changed from prior to next.
+// If isNew is true, the resource is reported as seen for the first time.
+func NewChangeData(prior, next map[string]interface{}, isNew bool) *ChangeData {
+	if prior == nil {
+		prior = make(map[string]interface{})
+	}
+	if next == nil {
+		next = make(map[string]interface{})
+	}
+	return &ChangeData{prior, next, isNew}
+}
+
+// IsNewResource reports the value isNew the ChangeData was created with.
+func (cd *ChangeData) IsNewResource() bool {
+	return cd.isNew
+}
+
+// HasChange reports whether the old and new values of key differ.
+func (cd *ChangeData) HasChange(key string) bool {
+	o, n := cd.GetChange(key)
+	return !Equal(o, n)
+}
+
+// GetChange returns the old and new value for a given key.
+func (cd *ChangeData) GetChange(key string) (interface{}, interface{}) {
+	o, _ := cd.prior.lookup(key)
+	n, _ := cd.next.lookup(key)
+	return o, n
+}
+
+// Get returns the new value for the given key, or nil if the key doesn't
+// exist.
+func (cd *ChangeData) Get(key string) interface{} {
+	v, _ := cd.next.lookup(key)
+	return v
+}
+
+// GetOk returns the new value for the given key and whether or not the key
+// exists.
+func (cd *ChangeData) GetOk(key string) (interface{}, bool) {
+	return cd.next.lookup(key)
+}
+
+// GetOkExists returns the new value for a given key and whether or not it is
+// set to a non-nil value. Like with schema.ResourceData, zero values such as
+// false are reported as existing when they are set explicitly.
+func (cd *ChangeData) GetOkExists(key string) (interface{}, bool) {
+	v, ok := cd.next.lookup(key)
+	return v, ok && !isNil(v)
+}
+
+// Set sets the new value for the given key.
+func (cd *ChangeData) Set(key string, value interface{}) error {
+	return cd.next.Set(key, value)
+}
+
+var _ ResourceData = (*ChangeData)(nil)
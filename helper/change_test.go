@@ -0,0 +1,95 @@
+package helper
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/internal/testing/expect"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestChangeData(t *testing.T) {
+	prior := map[string]interface{}{
+		"name": "foo",
+		"size": 1,
+		"list": []interface{}{
+			map[string]interface{}{"a": "x", "b": "y"},
+		},
+		"set": schema.NewSet(schema.HashString, []interface{}{"x", "y"}),
+	}
+	next := map[string]interface{}{
+		"name": "foo",
+		"size": 2,
+		"list": []interface{}{
+			map[string]interface{}{"a": "x", "b": "z"},
+		},
+		"set": schema.NewSet(schema.HashString, []interface{}{"y", "x"}),
+	}
+
+	d := NewChangeData(prior, next, false)
+
+	expect.Expect(t, d.IsNewResource(), false)
+	expect.Expect(t, d.HasChange("name"), false)
+	expect.Expect(t, d.HasChange("size"), true)
+	expect.Expect(t, d.HasChange("list"), true)
+	expect.Expect(t, d.HasChange("list.0.a"), false)
+	expect.Expect(t, d.HasChange("list.0.b"), true)
+	expect.Expect(t, d.HasChange("set"), false)
+	expect.Expect(t, d.HasChange("missing"), false)
+
+	o, n := d.GetChange("list.0.b")
+	expect.Expect(t, o, "y")
+	expect.Expect(t, n, "z")
+
+	expect.Expect(t, d.Get("list.#"), 1)
+	expect.Expect(t, d.Get("set.#"), 2)
+	expect.Expect(t, d.Get("set."+strconv.Itoa(schema.HashString("x"))), "x")
+
+	_, ok := d.GetOkExists("list.0.c")
+	expect.Expect(t, ok, false)
+
+	d = NewChangeData(prior, prior, false)
+	expect.Expect(t, d.HasChange("list"), false)
+	expect.Expect(t, d.HasChange("size"), false)
+	expect.Expect(t, d.Get("list.0.b"), "y")
+}
+
+func TestChangeDataGetOkExists(t *testing.T) {
+	d := NewChangeData(nil, map[string]interface{}{"enabled": false, "count": 0, "name": nil}, true)
+
+	v, ok := d.GetOkExists("enabled")
+	expect.Expect(t, v, false)
+	expect.Expect(t, ok, true)
+
+	v, ok = d.GetOkExists("count")
+	expect.Expect(t, v, 0)
+	expect.Expect(t, ok, true)
+
+	_, ok = d.GetOkExists("name")
+	expect.Expect(t, ok, false)
+	_, ok = d.GetOkExists("missing")
+	expect.Expect(t, ok, false)
+}
+
+func TestChangeDataNew(t *testing.T) {
+	d := NewChangeData(nil, map[string]interface{}{"name": "foo"}, true)
+
+	expect.Expect(t, d.IsNewResource(), true)
+	expect.Expect(t, d.HasChange("name"), true)
+
+	o, n := d.GetChange("name")
+	expect.Expect(t, o, nil)
+	expect.Expect(t, n, "foo")
+
+	d.Set("name", "bar")
+	expect.Expect(t, d.Get("name"), "bar")
+}
+
+func TestEqual(t *testing.T) {
+	expect.Expect(t, Equal(MapData{"a": 1}, map[string]interface{}{"a": 1}), true)
+	expect.Expect(t, Equal([]interface{}{1}, []interface{}{2}), false)
+	expect.Expect(t, Equal(
+		schema.NewSet(schema.HashString, []interface{}{"a", "b"}),
+		schema.NewSet(schema.HashString, []interface{}{"b", "a"}),
+	), true)
+}
@@ -0,0 +1,87 @@
+package helper
+
+import (
+	"fmt"
+
+	"github.com/alexkappa/terraform-plugin-helper/internal/value"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// FromCty returns a ResourceData whose values change from prior to next, which
+// must be objects or maps such as the state and configuration of a resource
+// as represented by the SDK. The resource is new if prior is null.
+//
+// Values are converted to Terraform's internal representation. Objects and
+// maps are converted to a map[string]interface{}, lists and tuples to a
+// []interface{}, sets to a *schema.Set hashed by HashValue and numbers to an
+// int if they are whole, or a float64 otherwise. Null and unknown values are
+// omitted, the same way as unset attributes of a schema.ResourceData.
+func FromCty(prior, next cty.Value) (*ChangeData, error) {
+	o, err := fromCtyObject(prior)
+	if err != nil {
+		return nil, err
+	}
+	n, err := fromCtyObject(next)
+	if err != nil {
+		return nil, err
+	}
+	return NewChangeData(o, n, prior.IsNull()), nil
+}
+
+func fromCtyObject(v cty.Value) (map[string]interface{}, error) {
+	if v.IsNull() || !v.IsKnown() {
+		return nil, nil
+	}
+	if t := v.Type(); !t.IsObjectType() && !t.IsMapType() {
+		return nil, fmt.Errorf("helper: FromCty requires an object or map, got %s", t.FriendlyName())
+	}
+	out, err := fromCty(nil, v)
+	if err != nil {
+		return nil, err
+	}
+	return out.(map[string]interface{}), nil
+}
+
+func fromCty(p Path, v cty.Value) (interface{}, error) {
+	if v.IsNull() || !v.IsKnown() {
+		return nil, nil
+	}
+	t := v.Type()
+	switch {
+	case t.IsObjectType(), t.IsMapType():
+		out := make(map[string]interface{}, v.LengthInt())
+		for it := v.ElementIterator(); it.Next(); {
+			k, e := it.Element()
+			item, err := fromCty(append(p, Key(k.AsString())), e)
+			if err != nil {
+				return nil, err
+			}
+			if item != nil {
+				out[k.AsString()] = item
+			}
+		}
+		return out, nil
+	case t.IsListType(), t.IsTupleType(), t.IsSetType():
+		out := make([]interface{}, 0, v.LengthInt())
+		for it := v.ElementIterator(); it.Next(); {
+			_, e := it.Element()
+			item, err := fromCty(append(p, Index(len(out))), e)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, item)
+		}
+		if t.IsSetType() {
+			return schema.NewSet(HashValue, out), nil
+		}
+		return out, nil
+	case t == cty.String:
+		return v.AsString(), nil
+	case t == cty.Bool:
+		return v.True(), nil
+	case t == cty.Number:
+		return value.Number(v.AsBigFloat()), nil
+	}
+	return nil, fmt.Errorf("helper: unsupported type %s at %q", t.FriendlyName(), p.String())
+}
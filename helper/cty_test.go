@@ -0,0 +1,77 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/internal/testing/expect"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestFromCty(t *testing.T) {
+	prior := cty.ObjectVal(map[string]cty.Value{
+		"name":  cty.StringVal("foo"),
+		"size":  cty.NumberIntVal(1),
+		"ratio": cty.NullVal(cty.Number),
+		"tags":  cty.SetVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+		"mounts": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{"target": cty.StringVal("/mnt")}),
+		}),
+	})
+	next := cty.ObjectVal(map[string]cty.Value{
+		"name":  cty.StringVal("foo"),
+		"size":  cty.NumberIntVal(2),
+		"ratio": cty.NumberFloatVal(1.5),
+		"tags":  cty.SetVal([]cty.Value{cty.StringVal("b"), cty.StringVal("a")}),
+		"mounts": cty.ListVal([]cty.Value{
+			cty.ObjectVal(map[string]cty.Value{"target": cty.UnknownVal(cty.String)}),
+		}),
+	})
+
+	d, err := FromCty(prior, next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, d.IsNewResource(), false)
+	expect.Expect(t, d.HasChange("name"), false)
+	expect.Expect(t, d.HasChange("size"), true)
+	expect.Expect(t, d.HasChange("tags"), false)
+	expect.Expect(t, d.Get("size"), 2)
+	expect.Expect(t, d.Get("ratio"), 1.5)
+	expect.Expect(t, d.Get("tags"), schema.NewSet(HashValue, []interface{}{"a", "b"}))
+	expect.Expect(t, d.Get("mounts.#"), 1)
+
+	_, ok := d.GetOk("mounts.0.target")
+	expect.Expect(t, ok, false)
+
+	d, err = FromCty(cty.NullVal(prior.Type()), next)
+	expect.Expect(t, err, nil)
+	expect.Expect(t, d.IsNewResource(), true)
+	expect.Expect(t, d.HasChange("name"), true)
+
+	_, err = FromCty(cty.StringVal("foo"), next)
+	expect.Expect(t, err.Error(), "helper: FromCty requires an object or map, got string")
+}
+
+func TestFromCtyZero(t *testing.T) {
+	next := cty.ObjectVal(map[string]cty.Value{
+		"enabled": cty.False,
+		"count":   cty.Zero,
+		"name":    cty.NullVal(cty.String),
+	})
+	d, err := FromCty(cty.NullVal(next.Type()), next)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, ok := d.GetOkExists("enabled")
+	expect.Expect(t, v, false)
+	expect.Expect(t, ok, true)
+
+	v, ok = d.GetOkExists("count")
+	expect.Expect(t, v, 0)
+	expect.Expect(t, ok, true)
+
+	_, ok = d.GetOkExists("name")
+	expect.Expect(t, ok, false)
+}
@@ -1,7 +1,11 @@
 package helper
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
+
+	"github.com/alexkappa/terraform-plugin-helper/internal/value"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
@@ -43,9 +47,30 @@ type ResourceData interface {
 
 var _ ResourceData = (*schema.ResourceData)(nil)
 
+// A Wrapper is a ResourceData wrapping another one, such as a Recorder or the
+// data returned by expand.Collect and expand.WithMode. Functions looking for a
+// particular wrapper use it to walk the chain of wrappers.
+type Wrapper interface {
+	// Unwrap returns the wrapped ResourceData.
+	Unwrap() ResourceData
+}
+
+// Unwrap returns the ResourceData wrapped by d, or nil if d isn't a Wrapper.
+func Unwrap(d ResourceData) ResourceData {
+	if w, ok := d.(Wrapper); ok {
+		return w.Unwrap()
+	}
+	return nil
+}
+
 // MapData wraps a map satisfying the Data interface, so it can be used in the
 // accessor methods defined below.
 //
+// Keys may be dotted paths into nested maps, lists and sets, such as
+// "task_spec.0.container_spec.0.mounts.1606541327.target", which are resolved
+// the same way as they are with schema.ResourceData. The "#" and "%" keys hold
+// the number of elements of a list, set or map.
+//
 // It is not possible to fully mirror the functionality of Data as some
 // information available to schema.ResourceData is lost when dealing with maps.
 type MapData map[string]interface{}
@@ -57,42 +82,53 @@ func (md MapData) IsNewResource() bool {
 
 // HasChange reports whether the key exists in the map.
 func (md MapData) HasChange(key string) bool {
-	_, ok := md[key]
+	_, ok := md.lookup(key)
 	return ok
 }
 
 // GetChange returns the old and new value for a given key. The old and new
 // values will always be the same.
 func (md MapData) GetChange(key string) (interface{}, interface{}) {
-	return md[key], md[key]
+	v, _ := md.lookup(key)
+	return v, v
 }
 
 // Get returns the data for the given key, or nil if the key doesn't exist in
 // the map.
 func (md MapData) Get(key string) interface{} {
-	return md[key]
+	v, _ := md.lookup(key)
+	return v
 }
 
 // GetOk returns the data for the given key and whether or not the key has been
 // set to a non-zero value at some point.
 func (md MapData) GetOk(key string) (interface{}, bool) {
-	v, ok := md[key]
-	return v, ok
+	return md.lookup(key)
 }
 
 // GetOkExists returns the data for a given key and whether or not the key has
 // been set to a non-nil and non-zero value.
 func (md MapData) GetOkExists(key string) (interface{}, bool) {
-	v, ok := md[key]
+	v, ok := md.lookup(key)
 	return v, ok && !isNil(v) && !isZero(v)
 }
 
-// Set sets the value for the given key.
-func (md MapData) Set(key string, value interface{}) error {
-	md[key] = value
+// Set sets the value for the given key. Setting a nested key replaces the
+// enclosing top level value with a copy holding the new value.
+func (md MapData) Set(key string, v interface{}) error {
+	p := strings.Split(key, ".")
+	top, err := value.SetIn(md[p[0]], p[1:], v)
+	if err != nil {
+		return fmt.Errorf("unable to set %q: %s", key, err)
+	}
+	md[p[0]] = top
 	return nil
 }
 
+func (md MapData) lookup(key string) (interface{}, bool) {
+	return value.Lookup(map[string]interface{}(md), key)
+}
+
 func isNil(v interface{}) bool {
 	return v == nil
 }
@@ -1,6 +1,11 @@
 package helper
 
-import "testing"
+import (
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
 
 func TestMapData(t *testing.T) {
 	d := MapData{
@@ -23,3 +28,61 @@ func TestMapData(t *testing.T) {
 		}
 	}
 }
+
+func TestMapDataNested(t *testing.T) {
+	set := schema.NewSet(schema.HashString, []interface{}{"x", "y"})
+	hash := strconv.Itoa(schema.HashString("x"))
+
+	d := MapData{
+		"list": []interface{}{
+			map[string]interface{}{
+				"foo":    "bar",
+				"labels": map[string]interface{}{"a": "b"},
+				"set":    set,
+			},
+		},
+	}
+
+	for key, expect := range map[string]interface{}{
+		"list.#":             1,
+		"list.0.foo":         "bar",
+		"list.0.labels.%":    1,
+		"list.0.labels.a":    "b",
+		"list.0.set.#":       2,
+		"list.0.set." + hash: "x",
+	} {
+		if v, ok := d.GetOk(key); !ok || v != expect {
+			t.Errorf("d.GetOk(%s) should return %v, instead it returned %v, %t", key, expect, v, ok)
+		}
+		if !d.HasChange(key) {
+			t.Errorf("d.HasChange(%s) should report true", key)
+		}
+	}
+
+	for _, key := range []string{"list.1.foo", "list.0.baz", "list.foo", "list.0.set.123"} {
+		if _, ok := d.GetOk(key); ok {
+			t.Errorf("d.GetOk(%s) should report ok == false", key)
+		}
+	}
+
+	if err := d.Set("list.0.foo", "baz"); err != nil {
+		t.Fatal(err)
+	}
+	if v := d.Get("list.0.foo"); v != "baz" {
+		t.Errorf("d.Get(list.0.foo) should return baz, instead it returned %v", v)
+	}
+
+	if err := d.Set("list.0.set."+hash, "z"); err != nil {
+		t.Fatal(err)
+	}
+	if v := d.Get("list.0.set." + strconv.Itoa(schema.HashString("z"))); v != "z" {
+		t.Errorf("Expected set element to be replaced, instead it was %v", v)
+	}
+	if v := set.Len(); v != 2 || !set.Contains("x") {
+		t.Error("Expected the original set to be left untouched")
+	}
+
+	if err := d.Set("list.5.foo", "baz"); err == nil {
+		t.Error("d.Set(list.5.foo) should return an error")
+	}
+}
@@ -0,0 +1,47 @@
+package helper
+
+import (
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// Equal reports whether a and b are deeply equal. Unlike reflect.DeepEqual,
+// sets are compared by their elements and MapData values are considered equal
+// to maps holding the same elements.
+func Equal(a, b interface{}) bool {
+	if m, ok := a.(MapData); ok {
+		a = map[string]interface{}(m)
+	}
+	if m, ok := b.(MapData); ok {
+		b = map[string]interface{}(m)
+	}
+	switch a := a.(type) {
+	case *schema.Set:
+		b, ok := b.(*schema.Set)
+		return ok && a.Equal(b)
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, v := range a {
+			if w, ok := b[k]; !ok || !Equal(v, w) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !Equal(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
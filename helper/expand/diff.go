@@ -0,0 +1,404 @@
+package expand
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/alexkappa/terraform-plugin-helper/internal/value"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// An Update holds the old and new value of an element which has changed.
+type Update struct {
+	Old  map[string]interface{}
+	New  map[string]interface{}
+	Path helper.Path // full path of the element in the new value
+}
+
+// DiffBy accesses the value held by key, which may be a set or a list of nested
+// blocks, and compares its changes if any. Unlike Diff, elements are matched by
+// the key returned by identity rather than their value, so an element which
+// only had some of its attributes changed is returned as an update instead of
+// being both removed and added.
+//
+//	add, rm, update := DiffBy(d, "mounts", func(m map[string]interface{}) string {
+//		return m["target"].(string)
+//	})
+//
+// Elements are returned in the order they appear in the old or new value.
+func DiffBy(d helper.ResourceData, key string, identity func(map[string]interface{}) string) (add, rm []map[string]interface{}, update []Update) {
+	if d.IsNewResource() {
+		add, _ = blocks(nil, d.Get(key))
+		return add, nil, nil
+	}
+	if !d.HasChange(key) {
+		return
+	}
+	o, n := d.GetChange(key)
+	ob, _ := blocks(nil, o)
+	nb, paths := blocks(path(d, key), n)
+
+	byID := make(map[string]map[string]interface{}, len(ob))
+	for _, m := range ob {
+		byID[identity(m)] = m
+	}
+	seen := make(map[string]bool, len(nb))
+	for i, m := range nb {
+		id := identity(m)
+		seen[id] = true
+		switch om, ok := byID[id]; {
+		case !ok:
+			add = append(add, m)
+		case !helper.Equal(om, m):
+			update = append(update, Update{om, m, paths[i]})
+		}
+	}
+	for _, m := range ob {
+		if !seen[identity(m)] {
+			rm = append(rm, m)
+		}
+	}
+	return
+}
+
+// blocks returns the elements of a list or set of nested blocks held at path
+// p, along with their full path.
+func blocks(p helper.Path, v interface{}) ([]map[string]interface{}, []helper.Path) {
+	var (
+		items  []interface{}
+		pathOf = p.Index
+	)
+	switch v := v.(type) {
+	case []interface{}:
+		items = v
+	case *schema.Set:
+		items = v.List()
+		pathOf = func(i int) helper.Path { return p.SetHash(value.HashCode(v, items[i])) }
+	}
+	out := make([]map[string]interface{}, 0, len(items))
+	paths := make([]helper.Path, 0, len(items))
+	for i, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			out = append(out, m)
+			paths = append(paths, pathOf(i))
+		}
+	}
+	return out, paths
+}
+
+// An EditKind is the kind of an Edit.
+type EditKind int
+
+// The kinds of edits returned by ListDiff.
+const (
+	Insert EditKind = iota // an element is inserted
+	Delete                 // an element is deleted
+	Move                   // an element is moved to another index
+	Modify                 // an element has changed value
+)
+
+func (k EditKind) String() string {
+	switch k {
+	case Insert:
+		return "insert"
+	case Delete:
+		return "delete"
+	case Move:
+		return "move"
+	case Modify:
+		return "modify"
+	}
+	return "EditKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// An Edit is a single step of an edit script transforming the old value of a
+// list to its new value. Indexes refer to the list as it stands once the edits
+// preceding it in the script have been applied, so the script can be applied
+// in order, such as by issuing the corresponding calls to an API.
+type Edit struct {
+	Kind EditKind
+	From int         // index of the element before the edit, or -1 for inserts
+	To   int         // index of the element after the edit, or -1 for deletes
+	Old  interface{} // old value of the element, if any
+	New  interface{} // new value of the element, if any
+
+	// Path is the full path of the element in the new value, or in the old
+	// value for deletes. Unlike From and To, it doesn't depend on the edits
+	// preceding it.
+	Path helper.Path
+}
+
+// ListDiff accesses the value held by key, which must be a list, and returns an
+// edit script transforming its old value to its new one.
+//
+// Elements are matched by value, so reordered elements are reported as moves.
+// An element deleted from an index where another element is inserted is
+// reported as a modification instead, unless the element would have to be
+// moved.
+//
+// The script lists deletions first, by descending index, followed by moves,
+// modifications and insertions by ascending index. A move removes an element
+// from index From and inserts it back at index To. The number of moves is kept
+// to a minimum.
+func ListDiff(d helper.ResourceData, key string) []Edit {
+	return listDiff(d, key, nil)
+}
+
+// ListDiffBy works like ListDiff, except that elements are matched by the key
+// returned by identity. An element whose identity is kept but its value
+// changed is reported as a modification, as well as a move if its index
+// changed.
+func ListDiffBy(d helper.ResourceData, key string, identity func(interface{}) string) []Edit {
+	return listDiff(d, key, identity)
+}
+
+func listDiff(d helper.ResourceData, key string, identity func(interface{}) string) []Edit {
+	if d.IsNewResource() {
+		n, _ := d.Get(key).([]interface{})
+		return diffLists(path(d, key), nil, n, identity)
+	}
+	if !d.HasChange(key) {
+		return nil
+	}
+	o, n := d.GetChange(key)
+	ol, _ := o.([]interface{})
+	nl, _ := n.([]interface{})
+	return diffLists(path(d, key), ol, nl, identity)
+}
+
+// diffLists returns the edit script transforming o to n, the old and new value
+// of the list at path p.
+func diffLists(p helper.Path, o, n []interface{}, identity func(interface{}) string) []Edit {
+	byValue := identity == nil
+	if byValue {
+		identity = valueIdentity()
+	}
+
+	// Match each element of n with an element of o of the same identity.
+	pending := make(map[string][]int)
+	for i, v := range o {
+		id := identity(v)
+		pending[id] = append(pending[id], i)
+	}
+	match := make([]int, len(n))
+	matched := make([]bool, len(o))
+	for j, v := range n {
+		id := identity(v)
+		match[j] = -1
+		if q := pending[id]; len(q) > 0 {
+			match[j], pending[id] = q[0], q[1:]
+			matched[q[0]] = true
+		}
+	}
+	keep := increasing(match)
+	if byValue {
+		collapse(match, matched, keep)
+	}
+
+	var edits []Edit
+	for i := len(o) - 1; i >= 0; i-- {
+		if !matched[i] {
+			edits = append(edits, Edit{Delete, i, -1, o[i], nil, p.Index(i)})
+		}
+	}
+
+	// The remaining elements of o are moved to the order they have in n,
+	// leaving those to keep in place.
+	var cur, order []int
+	for i := range o {
+		if matched[i] {
+			cur = append(cur, i)
+		}
+	}
+	for j, i := range match {
+		if i < 0 {
+			continue
+		}
+		if !keep[j] {
+			from := indexOf(cur, i)
+			cur = append(cur[:from], cur[from+1:]...)
+			to := 0
+			if len(order) > 0 {
+				to = indexOf(cur, order[len(order)-1]) + 1
+			}
+			cur = append(cur[:to], append([]int{i}, cur[to:]...)...)
+			edits = append(edits, Edit{Move, from, to, o[i], n[j], p.Index(j)})
+		}
+		order = append(order, i)
+	}
+
+	k := 0
+	for j, i := range match {
+		if i < 0 {
+			continue
+		}
+		if !helper.Equal(o[i], n[j]) {
+			edits = append(edits, Edit{Modify, k, k, o[i], n[j], p.Index(j)})
+		}
+		k++
+	}
+	for j, i := range match {
+		if i < 0 {
+			edits = append(edits, Edit{Insert, -1, j, nil, n[j], p.Index(j)})
+		}
+	}
+	return edits
+}
+
+// collapse matches an unmatched element of n with the unmatched element of o
+// at the same index, so that a deletion and an insertion at the same index are
+// reported as a modification instead. Elements are only matched if they can be
+// kept in place.
+func collapse(match []int, matched, keep []bool) {
+	for j, i := range match {
+		if i >= 0 || j >= len(matched) || matched[j] || !inOrder(match, keep, j, j) {
+			continue
+		}
+		match[j], matched[j], keep[j] = j, true, true
+	}
+}
+
+// inOrder reports whether the element of o at index i can be kept at index j
+// of n, between the elements which are kept in place.
+func inOrder(match []int, keep []bool, i, j int) bool {
+	for k, m := range match {
+		switch {
+		case !keep[k]:
+		case k < j && m > i, k > j && m < i:
+			return false
+		}
+	}
+	return true
+}
+
+func indexOf(s []int, v int) int {
+	for k, w := range s {
+		if w == v {
+			return k
+		}
+	}
+	return -1
+}
+
+// valueIdentity returns an identity function assigning the same identity to
+// equal values.
+func valueIdentity() func(interface{}) string {
+	var classes []interface{}
+	return func(v interface{}) string {
+		for i, c := range classes {
+			if helper.Equal(c, v) {
+				return strconv.Itoa(i)
+			}
+		}
+		classes = append(classes, v)
+		return strconv.Itoa(len(classes) - 1)
+	}
+}
+
+// increasing returns which elements of match, ignoring negative ones, form its
+// longest increasing subsequence. Those are the elements that can be left in
+// place, while the rest need to be moved.
+func increasing(match []int) []bool {
+	var (
+		tails []int // index into match of the smallest tail of each length
+		prev  = make([]int, len(match))
+	)
+	for j, i := range match {
+		if i < 0 {
+			continue
+		}
+		k := sort.Search(len(tails), func(k int) bool { return match[tails[k]] >= i })
+		prev[j] = -1
+		if k > 0 {
+			prev[j] = tails[k-1]
+		}
+		if k == len(tails) {
+			tails = append(tails, j)
+		} else {
+			tails[k] = j
+		}
+	}
+	keep := make([]bool, len(match))
+	if len(tails) > 0 {
+		for j := tails[len(tails)-1]; j >= 0; j = prev[j] {
+			keep[j] = true
+		}
+	}
+	return keep
+}
+
+// A Change holds the old and new value of a map element which has changed.
+type Change struct {
+	Old interface{}
+	New interface{}
+}
+
+// MapChanges describes the changes of a map, such as a set of labels or tags.
+type MapChanges struct {
+	Added   map[string]interface{} // new elements
+	Removed map[string]interface{} // removed elements, holding their old value
+	Changed map[string]Change      // elements whose value has changed
+	Path    helper.Path            // full path of the map, extended by MapKey for its elements
+}
+
+// Set returns the elements which need to be set, which are the added and
+// changed elements along with their new value.
+func (c MapChanges) Set() map[string]interface{} {
+	set := make(map[string]interface{}, len(c.Added)+len(c.Changed))
+	for k, v := range c.Added {
+		set[k] = v
+	}
+	for k, v := range c.Changed {
+		set[k] = v.New
+	}
+	return set
+}
+
+// Unset returns the sorted keys of the elements which need to be unset.
+func (c MapChanges) Unset() []string {
+	return value.SortedKeys(c.Removed)
+}
+
+// MapDiff accesses the value held by key, which must be a map, and compares its
+// changes if any. For new resources, all elements are reported as added.
+//
+// APIs with separate endpoints for setting and removing elements can use the
+// Set and Unset methods of the result.
+//
+//	changes := MapDiff(d, "tags")
+//	api.TagResource(id, changes.Set())
+//	api.UntagResource(id, changes.Unset())
+func MapDiff(d helper.ResourceData, key string) MapChanges {
+	c := MapChanges{
+		Added:   make(map[string]interface{}),
+		Removed: make(map[string]interface{}),
+		Changed: make(map[string]Change),
+		Path:    path(d, key),
+	}
+	var o, n interface{}
+	switch {
+	case d.IsNewResource():
+		n = d.Get(key)
+	case d.HasChange(key):
+		o, n = d.GetChange(key)
+	default:
+		return c
+	}
+	om, _ := o.(map[string]interface{})
+	nm, _ := n.(map[string]interface{})
+	for k, v := range nm {
+		switch ov, ok := om[k]; {
+		case !ok:
+			c.Added[k] = v
+		case !helper.Equal(ov, v):
+			c.Changed[k] = Change{ov, v}
+		}
+	}
+	for k, v := range om {
+		if _, ok := nm[k]; !ok {
+			c.Removed[k] = v
+		}
+	}
+	return c
+}
@@ -0,0 +1,244 @@
+package expand
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// stateOf returns the flattened state attributes of a resource created from
+// raw.
+func stateOf(t *testing.T, raw map[string]interface{}) map[string]string {
+	d := schema.TestResourceDataRaw(t, s, raw)
+	d.SetId("id")
+	return d.State().Attributes
+}
+
+func TestDiffBy(t *testing.T) {
+	for _, key := range []string{"list", "set"} {
+		d := updateData(t, s, stateOf(t, map[string]interface{}{
+			key: []interface{}{
+				map[string]interface{}{"foo": "a", "bar": 1},
+				map[string]interface{}{"foo": "b", "bar": 2},
+				map[string]interface{}{"foo": "c", "bar": 3},
+			},
+		}), map[string]interface{}{
+			key: []interface{}{
+				map[string]interface{}{"foo": "b", "bar": 4},
+				map[string]interface{}{"foo": "c", "bar": 3},
+				map[string]interface{}{"foo": "d", "bar": 5},
+			},
+		})
+
+		add, rm, update := DiffBy(d, key, func(m map[string]interface{}) string {
+			return m["foo"].(string)
+		})
+
+		Expect(t, add, []map[string]interface{}{{"foo": "d", "bar": 5}})
+		Expect(t, rm, []map[string]interface{}{{"foo": "a", "bar": 1}})
+		Expect(t, len(update), 1)
+		Expect(t, update[0].Old, map[string]interface{}{"foo": "b", "bar": 2})
+		Expect(t, update[0].New, map[string]interface{}{"foo": "b", "bar": 4})
+		if key == "list" {
+			Expect(t, update[0].Path.String(), "list.0")
+		} else {
+			Expect(t, update[0].Path[0], helper.Key("set"))
+			if _, ok := update[0].Path[1].(helper.SetHash); !ok {
+				t.Errorf("Expected the update of set to be at a SetHash step, got %q", update[0].Path)
+			}
+		}
+	}
+}
+
+func TestDiffByNew(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"set": []interface{}{
+			map[string]interface{}{"foo": "a", "bar": 1},
+		},
+	})
+	add, rm, update := DiffBy(d, "set", func(m map[string]interface{}) string {
+		return m["foo"].(string)
+	})
+	Expect(t, add, []map[string]interface{}{{"foo": "a", "bar": 1}})
+	Expect(t, len(rm), 0)
+	Expect(t, len(update), 0)
+}
+
+func TestListDiff(t *testing.T) {
+	list := func(v ...interface{}) []interface{} { return v }
+	path := func(i int) helper.Path { return helper.Path{helper.Key("list"), helper.Index(i)} }
+
+	for _, test := range []struct {
+		o, n  []interface{}
+		edits []Edit
+	}{
+		{
+			list("a", "b", "c"),
+			list("a", "b", "c"),
+			nil,
+		},
+		{
+			list("a", "b", "c"),
+			list("c", "a", "b"),
+			[]Edit{{Move, 2, 0, "c", "c", path(0)}},
+		},
+		{
+			list("a", "b", "c"),
+			list("a", "x", "c", "d"),
+			[]Edit{
+				{Modify, 1, 1, "b", "x", path(1)},
+				{Insert, -1, 3, nil, "d", path(3)},
+			},
+		},
+		{
+			list("a", "b", "c", "d"),
+			list("b", "d"),
+			[]Edit{
+				{Delete, 2, -1, "c", nil, path(2)},
+				{Delete, 0, -1, "a", nil, path(0)},
+			},
+		},
+	} {
+		Expect(t, diffLists(helper.Path{helper.Key("list")}, test.o, test.n, nil), test.edits)
+	}
+}
+
+// apply applies edits to a copy of o.
+func apply(o []interface{}, edits []Edit) []interface{} {
+	l := append([]interface{}{}, o...)
+	for _, e := range edits {
+		switch e.Kind {
+		case Delete:
+			l = append(l[:e.From], l[e.From+1:]...)
+		case Move:
+			v := l[e.From]
+			l = append(l[:e.From], l[e.From+1:]...)
+			l = append(l[:e.To], append([]interface{}{v}, l[e.To:]...)...)
+		case Modify:
+			l[e.To] = e.New
+		case Insert:
+			l = append(l[:e.To], append([]interface{}{e.New}, l[e.To:]...)...)
+		}
+	}
+	return l
+}
+
+func TestListDiffApply(t *testing.T) {
+	list := func(s string) []interface{} {
+		l := []interface{}{}
+		for _, r := range s {
+			l = append(l, string(r))
+		}
+		return l
+	}
+
+	for _, test := range []struct {
+		o, n  string
+		moves int
+	}{
+		{"abc", "cb", 1},
+		{"abcd", "dc", 1},
+		{"abc", "cxa", 1},
+		{"abcde", "edcba", 4},
+		{"aab", "baa", 1},
+		{"abcdef", "fxbyda", 2},
+		{"", "ab", 0},
+		{"ab", "", 0},
+	} {
+		edits := diffLists(nil, list(test.o), list(test.n), nil)
+		Expect(t, apply(list(test.o), edits), list(test.n))
+
+		moves := 0
+		for _, e := range edits {
+			if e.Kind == Move {
+				moves++
+			}
+		}
+		if moves != test.moves {
+			t.Errorf("Expected %q -> %q to move %d elements, got %v", test.o, test.n, test.moves, edits)
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for k := 0; k < 500; k++ {
+		o, n := make([]interface{}, r.Intn(8)), make([]interface{}, r.Intn(8))
+		for i := range o {
+			o[i] = string(rune('a' + r.Intn(6)))
+		}
+		for i := range n {
+			n[i] = string(rune('a' + r.Intn(6)))
+		}
+		if got := apply(o, diffLists(nil, o, n, nil)); !helper.Equal(got, n) {
+			t.Fatalf("Expected the edits of %v -> %v to produce %v, got %v", o, n, n, got)
+		}
+		byFirst := func(v interface{}) string { return v.(string) }
+		if got := apply(o, diffLists(nil, o, n, byFirst)); !helper.Equal(got, n) {
+			t.Fatalf("Expected the edits of %v -> %v by identity to produce %v, got %v", o, n, n, got)
+		}
+	}
+}
+
+func TestListDiffBy(t *testing.T) {
+	d := updateData(t, s, stateOf(t, map[string]interface{}{
+		"list": []interface{}{
+			map[string]interface{}{"foo": "a", "bar": 1},
+			map[string]interface{}{"foo": "b", "bar": 2},
+			map[string]interface{}{"foo": "c", "bar": 3},
+		},
+	}), map[string]interface{}{
+		"list": []interface{}{
+			map[string]interface{}{"foo": "c", "bar": 3},
+			map[string]interface{}{"foo": "a", "bar": 4},
+			map[string]interface{}{"foo": "d", "bar": 5},
+		},
+	})
+
+	edits := ListDiffBy(d, "list", func(v interface{}) string {
+		return v.(map[string]interface{})["foo"].(string)
+	})
+
+	Expect(t, len(edits), 4)
+	Expect(t, edits[0].Kind, Delete)
+	Expect(t, edits[0].From, 1)
+	Expect(t, edits[1].Kind, Move)
+	Expect(t, edits[1].From, 1)
+	Expect(t, edits[1].To, 0)
+	Expect(t, edits[2].Kind, Modify)
+	Expect(t, edits[2].New, map[string]interface{}{"foo": "a", "bar": 4})
+	Expect(t, edits[3].Kind, Insert)
+	Expect(t, edits[3].To, 2)
+	Expect(t, edits[0].Path.String(), "list.1")
+	Expect(t, edits[1].Path.String(), "list.0")
+
+	Expect(t, len(ListDiff(d, "string")), 0)
+	Expect(t, Move.String(), "move")
+}
+
+func TestMapDiff(t *testing.T) {
+	d := updateData(t, s, stateOf(t, map[string]interface{}{
+		"map": map[string]interface{}{"a": "1", "b": "2", "c": "3"},
+	}), map[string]interface{}{
+		"map": map[string]interface{}{"a": "1", "b": "4", "d": "5"},
+	})
+
+	c := MapDiff(d, "map")
+	Expect(t, c.Added, map[string]interface{}{"d": "5"})
+	Expect(t, c.Removed, map[string]interface{}{"c": "3"})
+	Expect(t, c.Changed, map[string]Change{"b": {"2", "4"}})
+	Expect(t, c.Set(), map[string]interface{}{"b": "4", "d": "5"})
+	Expect(t, c.Unset(), []string{"c"})
+	Expect(t, c.Path.MapKey("b").String(), "map.b")
+
+	c = MapDiff(d, "string")
+	Expect(t, len(c.Added)+len(c.Removed)+len(c.Changed), 0)
+}
+
+func TestMapDiffNew(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"map": map[string]interface{}{"a": "1"},
+	})
+	Expect(t, MapDiff(d, "map").Set(), map[string]interface{}{"a": "1"})
+	Expect(t, len(MapDiff(d, "map").Unset()), 0)
+}
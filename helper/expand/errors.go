@@ -0,0 +1,92 @@
+package expand
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+)
+
+// A TypeError describes a value held by a key which could not be converted to
+// the requested Go type.
+type TypeError struct {
+	Path  helper.Path  // full path of the attribute
+	Value interface{}  // value held by the attribute
+	Type  reflect.Type // type the value could not be converted to
+}
+
+func (e *TypeError) Error() string {
+	return fmt.Sprintf("expand: cannot convert %T to %s at %q", e.Value, e.Type, e.Path.String())
+}
+
+// typeError returns a *TypeError for the value v held by key, which was
+// expected to be of the same type as t.
+func typeError(d helper.ResourceData, key string, v, t interface{}) error {
+	return &TypeError{path(d, key), v, reflect.TypeOf(t)}
+}
+
+// Errors is a list of errors collected during an expansion.
+type Errors []error
+
+// Err returns nil if no errors were collected, or the errors otherwise.
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e Errors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "\n")
+}
+
+// Unwrap returns the collected errors.
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+type collector struct {
+	helper.ResourceData
+	errs *Errors
+}
+
+func (c *collector) Unwrap() helper.ResourceData {
+	return c.ResourceData
+}
+
+// Collect wraps d so that accessors, which would otherwise panic when a value
+// can't be converted to the requested type, record the error instead. This
+// makes it possible for a single expansion to report all problems at once.
+//
+//	d, errs := Collect(d)
+//
+//	api.Name = String(d, "name")
+//	api.Size = Int64Ptr(d, "size")
+//
+//	if err := errs.Err(); err != nil {
+//		return err
+//	}
+//
+// The errors are recorded even when accessed through the data passed to
+// Iterator.Elem.
+func Collect(d helper.ResourceData) (helper.ResourceData, *Errors) {
+	c := &collector{d, new(Errors)}
+	return c, c.errs
+}
+
+// fail records err with the collector d is wrapping, or panics if there isn't
+// one.
+func fail(d helper.ResourceData, err error) {
+	for ; d != nil; d = helper.Unwrap(d) {
+		if c, ok := d.(*collector); ok {
+			*c.errs = append(*c.errs, err)
+			return
+		}
+	}
+	panic(err)
+}
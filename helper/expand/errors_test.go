@@ -0,0 +1,83 @@
+package expand
+
+import (
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+var rawErrors = map[string]interface{}{
+	"string": "hello!",
+	"int":    123,
+	"list": []interface{}{
+		map[string]interface{}{
+			"foo": "bar",
+			"bar": 123,
+		},
+	},
+}
+
+func TestTypeError(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, s, rawErrors)
+
+	_, err := StringE(d, "int")
+	te, ok := err.(*TypeError)
+	if !ok {
+		t.Fatalf("Expected a *TypeError, instead it was %T", err)
+	}
+	Expect(t, te.Path.String(), "int")
+	Expect(t, te.Value, 123)
+	Expect(t, te.Type.String(), "string")
+	Expect(t, te.Error(), `expand: cannot convert int to string at "int"`)
+
+	List(d, "list").Elem(func(d helper.ResourceData) {
+		_, err := BoolPtrE(d, "foo")
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		Expect(t, err.(*TypeError).Path.String(), "list.0.foo")
+	})
+
+	_, err = SetE(d, "list")
+	Expect(t, err.(*TypeError).Path.String(), "list")
+
+	_, _, err = DiffE(d, "list")
+	Expect(t, err.(*TypeError).Path.String(), "list")
+
+	_, err = MapE(d, "string")
+	Expect(t, err.(*TypeError).Path.String(), "string")
+}
+
+func TestTypeErrorPanic(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, s, rawErrors)
+	defer func() {
+		if _, ok := recover().(*TypeError); !ok {
+			t.Error("Expected a *TypeError panic")
+		}
+	}()
+	Int(d, "string")
+}
+
+func TestCollect(t *testing.T) {
+	d, errs := Collect(schema.TestResourceDataRaw(t, s, rawErrors))
+
+	Expect(t, String(d, "string"), "hello!")
+	Int(d, "string")
+	List(d, "list").Elem(func(d helper.ResourceData) {
+		Expect(t, String(d, "foo"), "bar")
+		String(d, "bar")
+	})
+	Slice(d, "int")
+
+	Expect(t, len(*errs), 3)
+	Expect(t, (*errs)[1].(*TypeError).Path.String(), "list.0.bar")
+	if errs.Err() == nil {
+		t.Error("Expected errs.Err() to be non-nil")
+	}
+
+	_, errs = Collect(d)
+	if errs.Err() != nil {
+		t.Error("Expected errs.Err() to be nil")
+	}
+}
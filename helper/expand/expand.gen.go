@@ -2,244 +2,526 @@
 
 package expand
 
-import "github.com/alexkappa/terraform-plugin-helper/helper"
+import (
+	"reflect"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+)
 
 // String accesses the value held by key and type asserts it as a string.
+//
+// If the value is not a string it panics, unless d was returned by Collect.
 func String(d helper.ResourceData, key string) (s string) {
+	s, err := StringE(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// StringE accesses the value held by key and type asserts it as a string.
+//
+// If the value is not a string a *TypeError is returned.
+func StringE(d helper.ResourceData, key string) (s string, err error) {
 	v, ok := get(d, key)
 	if ok {
-		s = v.(string)
+		if s, ok = v.(string); !ok {
+			err = typeError(d, key, v, s)
+		}
 	}
 	return
 }
 
 // StringPtr accesses the value held by key and type asserts it as a pointer to
 // a string.
+//
+// If the value is not a string it panics, unless d was returned by Collect.
 func StringPtr(d helper.ResourceData, key string) (s *string) {
+	s, err := StringPtrE(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// StringPtrE accesses the value held by key and type asserts it as a pointer to
+// a string.
+//
+// If the value is not a string a *TypeError is returned.
+func StringPtrE(d helper.ResourceData, key string) (s *string, err error) {
 	v, ok := get(d, key)
 	if ok {
-		tmp := v.(string)
+		tmp, ok := v.(string)
+		if !ok {
+			return nil, typeError(d, key, v, tmp)
+		}
 		s = &tmp
 	}
 	return
 }
 
 // Bool accesses the value held by key and type asserts it as a bool.
+//
+// If the value is not a bool it panics, unless d was returned by Collect.
 func Bool(d helper.ResourceData, key string) (b bool) {
+	b, err := BoolE(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// BoolE accesses the value held by key and type asserts it as a bool.
+//
+// If the value is not a bool a *TypeError is returned.
+func BoolE(d helper.ResourceData, key string) (b bool, err error) {
 	v, ok := get(d, key)
 	if ok {
-		b = v.(bool)
+		if b, ok = v.(bool); !ok {
+			err = typeError(d, key, v, b)
+		}
 	}
 	return
 }
 
-// BoolPtr accesses the value held by key and type asserts it as a pointer to
-// a bool.
+// BoolPtr accesses the value held by key and type asserts it as a pointer to a
+// bool.
+//
+// If the value is not a bool it panics, unless d was returned by Collect.
 func BoolPtr(d helper.ResourceData, key string) (b *bool) {
+	b, err := BoolPtrE(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// BoolPtrE accesses the value held by key and type asserts it as a pointer to a
+// bool.
+//
+// If the value is not a bool a *TypeError is returned.
+func BoolPtrE(d helper.ResourceData, key string) (b *bool, err error) {
 	v, ok := get(d, key)
 	if ok {
-		tmp := v.(bool)
+		tmp, ok := v.(bool)
+		if !ok {
+			return nil, typeError(d, key, v, tmp)
+		}
 		b = &tmp
 	}
 	return
 }
 
-// Int32 accesses the value held by key and type asserts it as a int32.
+// Int32 accesses the value held by key and converts it to a int32.
+//
+// If the value can't be converted to a int32 it panics, unless d was returned
+// by Collect.
 func Int32(d helper.ResourceData, key string) (i int32) {
+	i, err := Int32E(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// Int32E accesses the value held by key and converts it to a int32.
+//
+// If the value can't be converted to a int32 a *TypeError is returned.
+func Int32E(d helper.ResourceData, key string) (i int32, err error) {
 	v, ok := get(d, key)
 	if ok {
-		i = v.(int32)
+		err = assign(path(d, key), reflect.ValueOf(&i).Elem(), v)
 	}
 	return
 }
 
-// Int32Ptr accesses the value held by key and type asserts it as a pointer to
-// a int32.
+// Int32Ptr accesses the value held by key and converts it to a pointer to a
+// int32.
+//
+// If the value can't be converted to a int32 it panics, unless d was returned
+// by Collect.
 func Int32Ptr(d helper.ResourceData, key string) (i *int32) {
+	i, err := Int32PtrE(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// Int32PtrE accesses the value held by key and converts it to a pointer to a
+// int32.
+//
+// If the value can't be converted to a int32 a *TypeError is returned.
+func Int32PtrE(d helper.ResourceData, key string) (i *int32, err error) {
 	v, ok := get(d, key)
 	if ok {
-		tmp := v.(int32)
+		var tmp int32
+		if err = assign(path(d, key), reflect.ValueOf(&tmp).Elem(), v); err != nil {
+			return nil, err
+		}
 		i = &tmp
 	}
 	return
 }
 
-// Uint32 accesses the value held by key and type asserts it as a uint32.
+// Uint32 accesses the value held by key and converts it to a uint32.
+//
+// If the value can't be converted to a uint32 it panics, unless d was returned
+// by Collect.
 func Uint32(d helper.ResourceData, key string) (u uint32) {
+	u, err := Uint32E(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// Uint32E accesses the value held by key and converts it to a uint32.
+//
+// If the value can't be converted to a uint32 a *TypeError is returned.
+func Uint32E(d helper.ResourceData, key string) (u uint32, err error) {
 	v, ok := get(d, key)
 	if ok {
-		u = v.(uint32)
+		err = assign(path(d, key), reflect.ValueOf(&u).Elem(), v)
 	}
 	return
 }
 
-// Uint32Ptr accesses the value held by key and type asserts it as a pointer to
-// a uint32.
+// Uint32Ptr accesses the value held by key and converts it to a pointer to a
+// uint32.
+//
+// If the value can't be converted to a uint32 it panics, unless d was returned
+// by Collect.
 func Uint32Ptr(d helper.ResourceData, key string) (u *uint32) {
+	u, err := Uint32PtrE(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// Uint32PtrE accesses the value held by key and converts it to a pointer to a
+// uint32.
+//
+// If the value can't be converted to a uint32 a *TypeError is returned.
+func Uint32PtrE(d helper.ResourceData, key string) (u *uint32, err error) {
 	v, ok := get(d, key)
 	if ok {
-		tmp := v.(uint32)
+		var tmp uint32
+		if err = assign(path(d, key), reflect.ValueOf(&tmp).Elem(), v); err != nil {
+			return nil, err
+		}
 		u = &tmp
 	}
 	return
 }
 
-// Int64 accesses the value held by key and type asserts it as a int64.
+// Int64 accesses the value held by key and converts it to a int64.
+//
+// If the value can't be converted to a int64 it panics, unless d was returned
+// by Collect.
 func Int64(d helper.ResourceData, key string) (i int64) {
+	i, err := Int64E(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// Int64E accesses the value held by key and converts it to a int64.
+//
+// If the value can't be converted to a int64 a *TypeError is returned.
+func Int64E(d helper.ResourceData, key string) (i int64, err error) {
 	v, ok := get(d, key)
 	if ok {
-		i = v.(int64)
+		err = assign(path(d, key), reflect.ValueOf(&i).Elem(), v)
 	}
 	return
 }
 
-// Int64Ptr accesses the value held by key and type asserts it as a pointer to
-// a int64.
+// Int64Ptr accesses the value held by key and converts it to a pointer to a
+// int64.
+//
+// If the value can't be converted to a int64 it panics, unless d was returned
+// by Collect.
 func Int64Ptr(d helper.ResourceData, key string) (i *int64) {
+	i, err := Int64PtrE(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// Int64PtrE accesses the value held by key and converts it to a pointer to a
+// int64.
+//
+// If the value can't be converted to a int64 a *TypeError is returned.
+func Int64PtrE(d helper.ResourceData, key string) (i *int64, err error) {
 	v, ok := get(d, key)
 	if ok {
-		tmp := v.(int64)
+		var tmp int64
+		if err = assign(path(d, key), reflect.ValueOf(&tmp).Elem(), v); err != nil {
+			return nil, err
+		}
 		i = &tmp
 	}
 	return
 }
 
-// Uint64 accesses the value held by key and type asserts it as a uint64.
+// Uint64 accesses the value held by key and converts it to a uint64.
+//
+// If the value can't be converted to a uint64 it panics, unless d was returned
+// by Collect.
 func Uint64(d helper.ResourceData, key string) (u uint64) {
+	u, err := Uint64E(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// Uint64E accesses the value held by key and converts it to a uint64.
+//
+// If the value can't be converted to a uint64 a *TypeError is returned.
+func Uint64E(d helper.ResourceData, key string) (u uint64, err error) {
 	v, ok := get(d, key)
 	if ok {
-		u = v.(uint64)
+		err = assign(path(d, key), reflect.ValueOf(&u).Elem(), v)
 	}
 	return
 }
 
-// Uint64Ptr accesses the value held by key and type asserts it as a pointer to
-// a uint64.
+// Uint64Ptr accesses the value held by key and converts it to a pointer to a
+// uint64.
+//
+// If the value can't be converted to a uint64 it panics, unless d was returned
+// by Collect.
 func Uint64Ptr(d helper.ResourceData, key string) (u *uint64) {
+	u, err := Uint64PtrE(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// Uint64PtrE accesses the value held by key and converts it to a pointer to a
+// uint64.
+//
+// If the value can't be converted to a uint64 a *TypeError is returned.
+func Uint64PtrE(d helper.ResourceData, key string) (u *uint64, err error) {
 	v, ok := get(d, key)
 	if ok {
-		tmp := v.(uint64)
+		var tmp uint64
+		if err = assign(path(d, key), reflect.ValueOf(&tmp).Elem(), v); err != nil {
+			return nil, err
+		}
 		u = &tmp
 	}
 	return
 }
 
-// Int accesses the value held by key and type asserts it as a int.
+// Int accesses the value held by key and converts it to a int.
+//
+// If the value can't be converted to a int it panics, unless d was returned by
+// Collect.
 func Int(d helper.ResourceData, key string) (i int) {
+	i, err := IntE(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// IntE accesses the value held by key and converts it to a int.
+//
+// If the value can't be converted to a int a *TypeError is returned.
+func IntE(d helper.ResourceData, key string) (i int, err error) {
 	v, ok := get(d, key)
 	if ok {
-		i = v.(int)
+		err = assign(path(d, key), reflect.ValueOf(&i).Elem(), v)
 	}
 	return
 }
 
-// IntPtr accesses the value held by key and type asserts it as a pointer to
-// a int.
+// IntPtr accesses the value held by key and converts it to a pointer to a int.
+//
+// If the value can't be converted to a int it panics, unless d was returned by
+// Collect.
 func IntPtr(d helper.ResourceData, key string) (i *int) {
+	i, err := IntPtrE(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// IntPtrE accesses the value held by key and converts it to a pointer to a int.
+//
+// If the value can't be converted to a int a *TypeError is returned.
+func IntPtrE(d helper.ResourceData, key string) (i *int, err error) {
 	v, ok := get(d, key)
 	if ok {
-		tmp := v.(int)
+		var tmp int
+		if err = assign(path(d, key), reflect.ValueOf(&tmp).Elem(), v); err != nil {
+			return nil, err
+		}
 		i = &tmp
 	}
 	return
 }
 
-// Uint accesses the value held by key and type asserts it as a uint.
+// Uint accesses the value held by key and converts it to a uint.
+//
+// If the value can't be converted to a uint it panics, unless d was returned by
+// Collect.
 func Uint(d helper.ResourceData, key string) (u uint) {
+	u, err := UintE(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// UintE accesses the value held by key and converts it to a uint.
+//
+// If the value can't be converted to a uint a *TypeError is returned.
+func UintE(d helper.ResourceData, key string) (u uint, err error) {
 	v, ok := get(d, key)
 	if ok {
-		u = v.(uint)
+		err = assign(path(d, key), reflect.ValueOf(&u).Elem(), v)
 	}
 	return
 }
 
-// UintPtr accesses the value held by key and type asserts it as a pointer to
-// a uint.
+// UintPtr accesses the value held by key and converts it to a pointer to a
+// uint.
+//
+// If the value can't be converted to a uint it panics, unless d was returned by
+// Collect.
 func UintPtr(d helper.ResourceData, key string) (u *uint) {
+	u, err := UintPtrE(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// UintPtrE accesses the value held by key and converts it to a pointer to a
+// uint.
+//
+// If the value can't be converted to a uint a *TypeError is returned.
+func UintPtrE(d helper.ResourceData, key string) (u *uint, err error) {
 	v, ok := get(d, key)
 	if ok {
-		tmp := v.(uint)
+		var tmp uint
+		if err = assign(path(d, key), reflect.ValueOf(&tmp).Elem(), v); err != nil {
+			return nil, err
+		}
 		u = &tmp
 	}
 	return
 }
 
-// Float32 accesses the value held by key and type asserts it as a float32.
+// Float32 accesses the value held by key and converts it to a float32.
+//
+// If the value can't be converted to a float32 it panics, unless d was returned
+// by Collect.
 func Float32(d helper.ResourceData, key string) (f float32) {
-	v, ok := get(d, key)
-	if ok {
-		f = v.(float32)
+	f, err := Float32E(d, key)
+	if err != nil {
+		fail(d, err)
 	}
 	return
 }
 
-// Float32Ptr accesses the value held by key and type asserts it as a pointer to
-// a float32.
-func Float32Ptr(d helper.ResourceData, key string) (f *float32) {
+// Float32E accesses the value held by key and converts it to a float32.
+//
+// If the value can't be converted to a float32 a *TypeError is returned.
+func Float32E(d helper.ResourceData, key string) (f float32, err error) {
 	v, ok := get(d, key)
 	if ok {
-		tmp := v.(float32)
-		f = &tmp
+		err = assign(path(d, key), reflect.ValueOf(&f).Elem(), v)
 	}
 	return
 }
 
-// Float64 accesses the value held by key and type asserts it as a float64.
-func Float64(d helper.ResourceData, key string) (f float64) {
-	v, ok := get(d, key)
-	if ok {
-		f = v.(float64)
+// Float32Ptr accesses the value held by key and converts it to a pointer to a
+// float32.
+//
+// If the value can't be converted to a float32 it panics, unless d was returned
+// by Collect.
+func Float32Ptr(d helper.ResourceData, key string) (f *float32) {
+	f, err := Float32PtrE(d, key)
+	if err != nil {
+		fail(d, err)
 	}
 	return
 }
 
-// Float64Ptr accesses the value held by key and type asserts it as a pointer to
-// a float64.
-func Float64Ptr(d helper.ResourceData, key string) (f *float64) {
+// Float32PtrE accesses the value held by key and converts it to a pointer to a
+// float32.
+//
+// If the value can't be converted to a float32 a *TypeError is returned.
+func Float32PtrE(d helper.ResourceData, key string) (f *float32, err error) {
 	v, ok := get(d, key)
 	if ok {
-		tmp := v.(float64)
+		var tmp float32
+		if err = assign(path(d, key), reflect.ValueOf(&tmp).Elem(), v); err != nil {
+			return nil, err
+		}
 		f = &tmp
 	}
 	return
 }
 
-// Complex64 accesses the value held by key and type asserts it as a complex64.
-func Complex64(d helper.ResourceData, key string) (c complex64) {
-	v, ok := get(d, key)
-	if ok {
-		c = v.(complex64)
+// Float64 accesses the value held by key and converts it to a float64.
+//
+// If the value can't be converted to a float64 it panics, unless d was returned
+// by Collect.
+func Float64(d helper.ResourceData, key string) (f float64) {
+	f, err := Float64E(d, key)
+	if err != nil {
+		fail(d, err)
 	}
 	return
 }
 
-// Complex64Ptr accesses the value held by key and type asserts it as a pointer to
-// a complex64.
-func Complex64Ptr(d helper.ResourceData, key string) (c *complex64) {
+// Float64E accesses the value held by key and converts it to a float64.
+//
+// If the value can't be converted to a float64 a *TypeError is returned.
+func Float64E(d helper.ResourceData, key string) (f float64, err error) {
 	v, ok := get(d, key)
 	if ok {
-		tmp := v.(complex64)
-		c = &tmp
+		err = assign(path(d, key), reflect.ValueOf(&f).Elem(), v)
 	}
 	return
 }
 
-// Complex128 accesses the value held by key and type asserts it as a complex128.
-func Complex128(d helper.ResourceData, key string) (c complex128) {
-	v, ok := get(d, key)
-	if ok {
-		c = v.(complex128)
+// Float64Ptr accesses the value held by key and converts it to a pointer to a
+// float64.
+//
+// If the value can't be converted to a float64 it panics, unless d was returned
+// by Collect.
+func Float64Ptr(d helper.ResourceData, key string) (f *float64) {
+	f, err := Float64PtrE(d, key)
+	if err != nil {
+		fail(d, err)
 	}
 	return
 }
 
-// Complex128Ptr accesses the value held by key and type asserts it as a pointer to
-// a complex128.
-func Complex128Ptr(d helper.ResourceData, key string) (c *complex128) {
+// Float64PtrE accesses the value held by key and converts it to a pointer to a
+// float64.
+//
+// If the value can't be converted to a float64 a *TypeError is returned.
+func Float64PtrE(d helper.ResourceData, key string) (f *float64, err error) {
 	v, ok := get(d, key)
 	if ok {
-		tmp := v.(complex128)
-		c = &tmp
+		var tmp float64
+		if err = assign(path(d, key), reflect.ValueOf(&tmp).Elem(), v); err != nil {
+			return nil, err
+		}
+		f = &tmp
 	}
 	return
 }
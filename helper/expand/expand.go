@@ -1,28 +1,54 @@
 // Package expand contains helper functions used to map terraform configuration
 // to an API object.
+//
+// By default, accessors only read values of new resources or values which have
+// changed, returning the zero value otherwise. Use WithMode to change this.
+//
+// Accessors of numeric types such as Int64 or Float32 convert from whichever
+// numeric type is held by the key, as schema.ResourceData stores TypeInt as an
+// int and TypeFloat as a float64. Strings holding numbers are parsed. Values
+// which do not fit in the requested type are reported as a *TypeError.
 package expand
 
 //go:generate go run gen.go > expand.gen.go
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/alexkappa/terraform-plugin-helper/internal/value"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/structure"
 )
 
 type data struct {
 	prefix string
+	path   helper.Path
 	helper.ResourceData
 }
 
 func dataAtKey(key string, d helper.ResourceData) helper.ResourceData {
-	return &data{key, d}
+	return &data{key, path(d, key), d}
 }
 
 func dataAtIndex(i int, d helper.ResourceData) helper.ResourceData {
-	return &data{strconv.Itoa(i), d}
+	return &data{strconv.Itoa(i), helper.PathOf(d).Index(i), d}
+}
+
+func dataAtHash(code int, d helper.ResourceData) helper.ResourceData {
+	return &data{strconv.Itoa(code), helper.PathOf(d).SetHash(code), d}
+}
+
+// Path returns the full path of d within the resource.
+func (d *data) Path() helper.Path {
+	return d.path
+}
+
+// Unwrap returns the data d is nested within.
+func (d *data) Unwrap() helper.ResourceData {
+	return d.ResourceData
 }
 
 func (d *data) IsNewResource() bool {
@@ -49,32 +75,125 @@ func (d *data) GetOkExists(key string) (interface{}, bool) {
 	return d.ResourceData.GetOkExists(d.prefix + "." + key)
 }
 
-var _ helper.ResourceData = (*data)(nil)
+// Set sets the value for the given key, relative to the prefix of d.
+//
+// As nested values can't be set on a schema.ResourceData directly, the value of
+// the enclosing top level attribute is rebuilt with the new value in place and
+// set as a whole. Note that changing an attribute of a set element which
+// contributes to its hash, will also change the address of the element.
+func (d *data) Set(key string, v interface{}) error {
+	p := strings.Split(path(d, key).String(), ".")
+	r := root(d)
+	top, err := value.SetIn(r.Get(p[0]), p[1:], v)
+	if err != nil {
+		return fmt.Errorf("expand: unable to set %q: %s", path(d, key), err)
+	}
+	return r.Set(p[0], top)
+}
+
+var (
+	_ helper.ResourceData = (*data)(nil)
+	_ helper.Pather       = (*data)(nil)
+	_ helper.Wrapper      = (*data)(nil)
+)
+
+// root returns the data holding the top level attributes of d, which is the
+// outermost data wrapped by d that doesn't wrap nested data. Wrappers around
+// the top level data, such as the one returned by Collect, are kept.
+func root(d helper.ResourceData) helper.ResourceData {
+	r := d
+	for ; d != nil; d = helper.Unwrap(d) {
+		if _, ok := d.(*data); ok {
+			r = helper.Unwrap(d)
+		}
+	}
+	return r
+}
+
+// path returns the full path of key, including the path of any nested data d
+// may be. Numeric steps of key are resolved against the value they step into,
+// so that the hash code of a set element results in a SetHash step rather than
+// an Index.
+func path(d helper.ResourceData, key string) helper.Path {
+	p := helper.PathOf(d)
+	for _, part := range strings.Split(key, ".") {
+		n, err := strconv.Atoi(part)
+		switch {
+		case err != nil:
+			p = p.Key(part)
+		case isSet(d, p):
+			p = p.SetHash(n)
+		default:
+			p = p.Index(n)
+		}
+	}
+	return p
+}
+
+// isSet reports whether the value at p, a full path within the resource, is a
+// set. The value is read from the innermost data d wraps, so that wrappers don't
+// observe the read.
+func isSet(d helper.ResourceData, p helper.Path) bool {
+	if len(p) == 0 {
+		return false
+	}
+	for w := helper.Unwrap(d); w != nil; w = helper.Unwrap(w) {
+		d = w
+	}
+	_, ok := d.Get(p.String()).(*schema.Set)
+	return ok
+}
 
 func get(d helper.ResourceData, key string) (v interface{}, ok bool) {
-	if d.IsNewResource() || d.HasChange(key) {
+	if modeOf(d).Read(d, key) {
 		v, ok = d.GetOkExists(key)
 	}
 	return
 }
 
 // Slice accesses the value held by key and type asserts it to a slice.
+//
+// If the value is not a slice it panics, unless d was returned by Collect.
 func Slice(d helper.ResourceData, key string) (s []interface{}) {
-	if d.IsNewResource() || d.HasChange(key) {
-		v, ok := d.GetOkExists(key)
-		if ok {
-			s = v.([]interface{})
+	s, err := SliceE(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// SliceE accesses the value held by key and type asserts it to a slice.
+//
+// If the value is not a slice a *TypeError is returned.
+func SliceE(d helper.ResourceData, key string) (s []interface{}, err error) {
+	v, ok := get(d, key)
+	if ok {
+		if s, ok = v.([]interface{}); !ok {
+			err = typeError(d, key, v, s)
 		}
 	}
 	return
 }
 
 // Map accesses the value held by key and type asserts it to a map.
+//
+// If the value is not a map it panics, unless d was returned by Collect.
 func Map(d helper.ResourceData, key string) (m map[string]interface{}) {
-	if d.IsNewResource() || d.HasChange(key) {
-		v, ok := d.GetOkExists(key)
-		if ok {
-			m = v.(map[string]interface{})
+	m, err := MapE(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// MapE accesses the value held by key and type asserts it to a map.
+//
+// If the value is not a map a *TypeError is returned.
+func MapE(d helper.ResourceData, key string) (m map[string]interface{}, err error) {
+	v, ok := get(d, key)
+	if ok {
+		if m, ok = v.(map[string]interface{}); !ok {
+			err = typeError(d, key, v, m)
 		}
 	}
 	return
@@ -82,28 +201,55 @@ func Map(d helper.ResourceData, key string) (m map[string]interface{}) {
 
 // List accesses the value held by key and returns an iterator able to go over
 // its elements.
+//
+// If the value is not a list it panics, unless d was returned by Collect.
 func List(d helper.ResourceData, key string) Iterator {
-	if d.IsNewResource() || d.HasChange(key) {
-		v, ok := d.GetOkExists(key)
-		if ok {
-			return &list{dataAtKey(key, d), v.([]interface{})}
+	it, err := ListE(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return it
+}
+
+// ListE accesses the value held by key and returns an iterator able to go over
+// its elements.
+//
+// If the value is not a list a *TypeError is returned.
+func ListE(d helper.ResourceData, key string) (Iterator, error) {
+	v, ok := get(d, key)
+	if ok {
+		l, ok := v.([]interface{})
+		if !ok {
+			return &list{}, typeError(d, key, v, l)
 		}
+		return &list{dataAtKey(key, d), l}, nil
 	}
-	return &list{}
+	return &list{}, nil
 }
 
 // Set accesses the value held by key, type asserts it to a set and returns an
 // iterator able to go over its elements.
+//
+// If the value is not a set, the iterator returned is empty.
 func Set(d helper.ResourceData, key string) Iterator {
-	if d.IsNewResource() || d.HasChange(key) {
-		v, ok := d.GetOkExists(key)
-		if ok {
-			if s, ok := v.(*schema.Set); ok {
-				return &set{dataAtKey(key, d), s}
-			}
+	it, _ := SetE(d, key)
+	return it
+}
+
+// SetE accesses the value held by key, type asserts it to a set and returns an
+// iterator able to go over its elements.
+//
+// If the value is not a set a *TypeError is returned.
+func SetE(d helper.ResourceData, key string) (Iterator, error) {
+	v, ok := get(d, key)
+	if ok {
+		s, ok := v.(*schema.Set)
+		if !ok {
+			return &set{nil, &schema.Set{}}, typeError(d, key, v, s)
 		}
+		return &set{dataAtKey(key, d), s}, nil
 	}
-	return &set{nil, &schema.Set{}}
+	return &set{nil, &schema.Set{}}, nil
 }
 
 // Iterator enables access to the elements of a list or set.
@@ -126,6 +272,9 @@ type Iterator interface {
 	// 	})
 	//
 	// making data access more intuitive for nested structures.
+	//
+	// Values set on d are written back to the enclosing attribute, which makes
+	// it possible to populate nested attributes in place.
 	Elem(func(d helper.ResourceData))
 
 	// Range iterates over all elements of the list, calling fn in each iteration.
@@ -161,14 +310,6 @@ type set struct {
 	s *schema.Set
 }
 
-func (s *set) hash(item interface{}) string {
-	code := s.s.F(item)
-	if code < 0 {
-		code = -code
-	}
-	return strconv.Itoa(code)
-}
-
 func (s *set) Range(fn func(key int, value interface{})) {
 	for key, value := range s.s.List() {
 		fn(key, value)
@@ -177,7 +318,7 @@ func (s *set) Range(fn func(key int, value interface{})) {
 
 func (s *set) Elem(fn func(helper.ResourceData)) {
 	for _, v := range s.s.List() {
-		fn(dataAtKey(s.hash(v), s.d))
+		fn(dataAtHash(value.HashCode(s.s, v), s.d))
 	}
 }
 
@@ -188,28 +329,61 @@ func (s *set) List() []interface{} {
 // Diff accesses the value held by key and type asserts it to a set. It then
 // compares it's changes if any and returns what needs to be added and what
 // needs to be removed.
+//
+// If the value is not a set it panics, unless d was returned by Collect.
 func Diff(d helper.ResourceData, key string) (add []interface{}, rm []interface{}) {
+	add, rm, err := DiffE(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// DiffE accesses the value held by key and type asserts it to a set. It then
+// compares it's changes if any and returns what needs to be added and what
+// needs to be removed.
+//
+// If the old or new value is not a set a *TypeError is returned.
+func DiffE(d helper.ResourceData, key string) (add []interface{}, rm []interface{}, err error) {
 	if d.IsNewResource() {
-		add = Set(d, key).List()
+		s, err := SetE(d, key)
+		return s.List(), nil, err
 	}
-	if d.HasChange(key) {
-		o, n := d.GetChange(key)
-		add = n.(*schema.Set).Difference(o.(*schema.Set)).List()
-		rm = o.(*schema.Set).Difference(n.(*schema.Set)).List()
+	if !d.HasChange(key) {
+		return
 	}
-	return
+	o, n := d.GetChange(key)
+	os, err := setOf(d, key, o)
+	if err != nil {
+		return nil, nil, err
+	}
+	ns, err := setOf(d, key, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ns.Difference(os).List(), os.Difference(ns).List(), nil
+}
+
+// setOf type asserts v, the old or new value of key, to a set. A nil value is
+// an empty set.
+func setOf(d helper.ResourceData, key string, v interface{}) (*schema.Set, error) {
+	if v == nil {
+		return &schema.Set{}, nil
+	}
+	s, ok := v.(*schema.Set)
+	if !ok {
+		return nil, typeError(d, key, v, s)
+	}
+	return s, nil
 }
 
 // JSON accesses the value held by key and unmarshals it into a map.
+//
+// If the value is not a string a *TypeError is returned.
 func JSON(d helper.ResourceData, key string) (m map[string]interface{}, err error) {
-	if d.IsNewResource() || d.HasChange(key) {
-		v, ok := d.GetOkExists(key)
-		if ok {
-			m, err = structure.ExpandJsonFromString(v.(string))
-			if err != nil {
-				return
-			}
-		}
+	s, err := StringE(d, key)
+	if err != nil || s == "" {
+		return
 	}
-	return
+	return structure.ExpandJsonFromString(s)
 }
@@ -1,3 +1,4 @@
+//go:build ignore
 // +build ignore
 
 package main
@@ -16,6 +17,7 @@ type data struct {
 	Func    string
 	Type    string
 	TypeVar string
+	Numeric bool
 }
 
 func main() {
@@ -28,7 +30,6 @@ func main() {
 		"int64", "uint64",
 		"int", "uint",
 		"float32", "float64",
-		"complex64", "complex128",
 	}
 
 	t := template.Must(template.New("tmpl").Parse(tmpl))
@@ -40,6 +41,7 @@ func main() {
 			Func:    strings.Title(types[i]),
 			Type:    types[i],
 			TypeVar: string(types[i][0]),
+			Numeric: types[i] != "string" && types[i] != "bool",
 		}
 	}
 
@@ -49,7 +51,7 @@ func main() {
 		errorf("Failed executing template. %s\n", err)
 	}
 
-	clean, err := format.Source(buf.Bytes())
+	clean, err := format.Source(wrap(buf.Bytes()))
 	if err != nil {
 		errorf("Failed formatting source code. %s\n%s\n", err, buf.Bytes())
 	}
@@ -60,6 +62,25 @@ func main() {
 	}
 }
 
+// wrap breaks the comment lines of src which are longer than 80 columns, as
+// those of the template are written on a single line regardless of the length
+// of the names substituted into them.
+func wrap(src []byte) []byte {
+	var out []string
+	for _, line := range strings.Split(string(src), "\n") {
+		for len(line) > 80 && strings.HasPrefix(line, "// ") {
+			i := strings.LastIndex(line[:81], " ")
+			if i <= len("//") {
+				break
+			}
+			out = append(out, line[:i])
+			line = "// " + line[i+1:]
+		}
+		out = append(out, line)
+	}
+	return []byte(strings.Join(out, "\n"))
+}
+
 func errorf(f string, v ...interface{}) {
 	fmt.Fprintf(os.Stderr, f, v...)
 	os.Exit(1)
@@ -69,24 +90,69 @@ const tmpl = `// Code generated by gen-accessors; DO NOT EDIT.
 
 package expand
 
-import "github.com/alexkappa/terraform-plugin-helper/helper"
+import (
+	"reflect"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+)
 
 {{range .}}
-// {{.Func}} accesses the value held by key and type asserts it as a {{.Type}}.
+// {{.Func}} accesses the value held by key and {{if .Numeric}}converts it to{{else}}type asserts it as{{end}} a {{.Type}}.
+//
+// If the value {{if .Numeric}}can't be converted to{{else}}is not{{end}} a {{.Type}} it panics, unless d was returned by Collect.
 func {{.Func}}(d helper.ResourceData, key string) ({{.TypeVar}} {{.Type}}) {
+	{{.TypeVar}}, err := {{.Func}}E(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// {{.Func}}E accesses the value held by key and {{if .Numeric}}converts it to{{else}}type asserts it as{{end}} a {{.Type}}.
+//
+// If the value {{if .Numeric}}can't be converted to{{else}}is not{{end}} a {{.Type}} a *TypeError is returned.
+func {{.Func}}E(d helper.ResourceData, key string) ({{.TypeVar}} {{.Type}}, err error) {
 	v, ok := get(d, key)
 	if ok {
-		{{.TypeVar}} = v.({{.Type}})
+{{- if .Numeric}}
+		err = assign(path(d, key), reflect.ValueOf(&{{.TypeVar}}).Elem(), v)
+{{- else}}
+		if {{.TypeVar}}, ok = v.({{.Type}}); !ok {
+			err = typeError(d, key, v, {{.TypeVar}})
+		}
+{{- end}}
 	}
 	return
 }
 
-// {{.Func}}Ptr accesses the value held by key and type asserts it as a pointer to
-// a {{.Type}}.
+// {{.Func}}Ptr accesses the value held by key and {{if .Numeric}}converts it to{{else}}type asserts it as{{end}} a pointer to a {{.Type}}.
+//
+// If the value {{if .Numeric}}can't be converted to{{else}}is not{{end}} a {{.Type}} it panics, unless d was returned by Collect.
 func {{.Func}}Ptr(d helper.ResourceData, key string) ({{.TypeVar}} *{{.Type}}) {
+	{{.TypeVar}}, err := {{.Func}}PtrE(d, key)
+	if err != nil {
+		fail(d, err)
+	}
+	return
+}
+
+// {{.Func}}PtrE accesses the value held by key and {{if .Numeric}}converts it to{{else}}type asserts it as{{end}} a pointer to a {{.Type}}.
+//
+// If the value {{if .Numeric}}can't be converted to{{else}}is not{{end}} a {{.Type}} a *TypeError is returned.
+func {{.Func}}PtrE(d helper.ResourceData, key string) ({{.TypeVar}} *{{.Type}}, err error) {
 	v, ok := get(d, key)
 	if ok {
-		tmp := v.({{.Type}})
+{{- if .Numeric}}
+		var tmp {{.Type}}
+		if err = assign(path(d, key), reflect.ValueOf(&tmp).Elem(), v); err != nil {
+			return nil, err
+		}
+{{- else}}
+		tmp, ok := v.({{.Type}})
+		if !ok {
+			return nil, typeError(d, key, v, tmp)
+		}
+{{- end}}
 		{{.TypeVar}} = &tmp
 	}
 	return
@@ -0,0 +1,57 @@
+package expand
+
+import (
+	"reflect"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+)
+
+// Get accesses the value held by key and converts it to T. Numbers are
+// converted between kinds, pointers are allocated and slices and maps are
+// converted element by element, the same way fields are with Struct.
+//
+// The boolean result reports whether the value is set and could be converted.
+func Get[T any](d helper.ResourceData, key string) (t T, ok bool) {
+	v, ok := get(d, key)
+	if !ok {
+		return
+	}
+	if err := assign(path(d, key), reflect.ValueOf(&t).Elem(), v); err != nil {
+		return t, false
+	}
+	return
+}
+
+// ListOf accesses the value held by key, which may be a list or a set, and
+// calls fn with each of its elements. The results of fn are returned as a
+// slice. If the value is neither a list nor a set it panics, unless d was
+// returned by Collect.
+//
+// The operation
+//
+//	var mounts []*Mount
+//	Set(d, "mounts").Elem(func(d helper.ResourceData) {
+//		mounts = append(mounts, &Mount{Target: String(d, "target")})
+//	})
+//
+// can be expressed as
+//
+//	mounts := ListOf(d, "mounts", func(d helper.ResourceData) *Mount {
+//		return &Mount{Target: String(d, "target")}
+//	})
+func ListOf[T any](d helper.ResourceData, key string, fn func(helper.ResourceData) T) (out []T) {
+	v, ok := get(d, key)
+	if !ok {
+		return
+	}
+	it, ok := iterator(d, key, v)
+	if !ok {
+		fail(d, &TypeError{path(d, key), v, reflect.TypeOf(out)})
+		return
+	}
+	out = make([]T, 0, len(it.List()))
+	it.Elem(func(d helper.ResourceData) {
+		out = append(out, fn(d))
+	})
+	return
+}
@@ -0,0 +1,52 @@
+package expand
+
+import (
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestGet(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"string": "hello!",
+		"int":    123,
+		"map":    map[string]interface{}{"foo": "bar"},
+	})
+
+	str, ok := Get[string](d, "string")
+	Expect(t, str, "hello!")
+	Expect(t, ok, true)
+
+	i, ok := Get[*int64](d, "int")
+	Expect(t, i, int64(123))
+	Expect(t, ok, true)
+
+	m, ok := Get[map[string]string](d, "map")
+	Expect(t, m, map[string]string{"foo": "bar"})
+	Expect(t, ok, true)
+
+	_, ok = Get[bool](d, "string")
+	Expect(t, ok, false)
+
+	_, ok = Get[bool](d, "bool")
+	Expect(t, ok, false)
+}
+
+func TestListOf(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"list": []interface{}{
+			map[string]interface{}{"foo": "a"},
+			map[string]interface{}{"foo": "b"},
+		},
+		"set": []interface{}{
+			map[string]interface{}{"foo": "c"},
+		},
+	})
+
+	foo := func(d helper.ResourceData) string { return String(d, "foo") }
+
+	Expect(t, ListOf(d, "list", foo), []string{"a", "b"})
+	Expect(t, ListOf(d, "set", foo), []string{"c"})
+	Expect(t, len(ListOf(d, "map", foo)), 0)
+}
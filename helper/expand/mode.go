@@ -0,0 +1,110 @@
+package expand
+
+import (
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// A Mode decides whether the value held by a key is read by the accessors or
+// if the zero value is returned instead.
+type Mode interface {
+	// Read reports whether the value held by key should be read.
+	Read(d helper.ResourceData, key string) bool
+}
+
+// The ModeFunc type is an adapter to allow the use of an ordinary function as a
+// Mode. If f is a function with the appropriate signature, ModeFunc(f) is a
+// Mode that calls f.
+type ModeFunc func(d helper.ResourceData, key string) bool
+
+// Read calls fn(d, key).
+func (fn ModeFunc) Read(d helper.ResourceData, key string) bool {
+	return fn(d, key)
+}
+
+var (
+	// Changed reads values of new resources, or values which have changed. It
+	// is the default mode and it is best suited for APIs accepting partial
+	// updates.
+	Changed Mode = ModeFunc(changed)
+
+	// Full always reads values, which is required by APIs expecting the full
+	// object on every update.
+	Full Mode = ModeFunc(func(helper.ResourceData, string) bool { return true })
+)
+
+func changed(d helper.ResourceData, key string) bool {
+	return d.IsNewResource() || d.HasChange(key)
+}
+
+// ChangedWithRequired reads values the same way Changed does. Additionally, it
+// reads values of attributes marked as Required in m, if any attribute of their
+// enclosing block has changed. Required attributes at the top level are always
+// read.
+//
+// This is useful for APIs which accept partial updates of nested objects, as
+// long as some of their fields, such as an identifier, are always present.
+func ChangedWithRequired(m map[string]*schema.Schema) Mode {
+	return ModeFunc(func(d helper.ResourceData, key string) bool {
+		if changed(d, key) {
+			return true
+		}
+		p := path(d, key)
+		if s := schemaAt(m, p); s == nil || !s.Required {
+			return false
+		}
+		parent := p[:len(p)-1]
+		return len(parent) == 0 || root(d).HasChange(parent.String())
+	})
+}
+
+// schemaAt returns the schema of the attribute at path p, or nil if m doesn't
+// describe one.
+func schemaAt(m map[string]*schema.Schema, p helper.Path) (s *schema.Schema) {
+	for _, step := range p {
+		key, ok := step.(helper.Key)
+		if !ok {
+			continue // element of the previous attribute
+		}
+		if s != nil {
+			r, ok := s.Elem.(*schema.Resource)
+			if !ok {
+				return nil
+			}
+			m = r.Schema
+		}
+		if s = m[string(key)]; s == nil {
+			return nil
+		}
+	}
+	return s
+}
+
+type moded struct {
+	helper.ResourceData
+	mode Mode
+}
+
+func (m *moded) Unwrap() helper.ResourceData {
+	return m.ResourceData
+}
+
+// WithMode wraps d so that accessors read values according to mode m. The mode
+// applies to the data passed to Iterator.Elem as well.
+//
+//	d = WithMode(d, Full)
+//
+//	api.Name = String(d, "name") // read even if "name" hasn't changed
+func WithMode(d helper.ResourceData, m Mode) helper.ResourceData {
+	return &moded{d, m}
+}
+
+// modeOf returns the mode d was wrapped with, or Changed if there isn't one.
+func modeOf(d helper.ResourceData) Mode {
+	for ; d != nil; d = helper.Unwrap(d) {
+		if m, ok := d.(*moded); ok {
+			return m.mode
+		}
+	}
+	return Changed
+}
@@ -0,0 +1,86 @@
+package expand
+
+import (
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/alexkappa/terraform-plugin-helper/helper/resourcetest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func updateData(t *testing.T, s map[string]*schema.Schema, state map[string]string, raw map[string]interface{}) *schema.ResourceData {
+	d, err := resourcetest.NewUpdateFromState(s, &terraform.InstanceState{ID: "id", Attributes: state}, raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestMode(t *testing.T) {
+	d := updateData(t, s, map[string]string{
+		"string":     "hello!",
+		"int":        "1",
+		"list.#":     "1",
+		"list.0.foo": "bar",
+		"list.0.bar": "1",
+	}, map[string]interface{}{
+		"string": "hello!",
+		"int":    2,
+		"list": []interface{}{
+			map[string]interface{}{"foo": "bar", "bar": 2},
+		},
+	})
+
+	required := map[string]*schema.Schema{
+		"string": {Type: schema.TypeString, Required: true},
+		"list": {
+			Type: schema.TypeList,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"foo": {Type: schema.TypeString, Required: true},
+				},
+			},
+		},
+	}
+
+	for _, test := range []struct {
+		mode   Mode
+		string string
+		int    int
+		foo    string
+	}{
+		{Changed, "", 2, ""},
+		{Full, "hello!", 2, "bar"},
+		{ChangedWithRequired(required), "hello!", 2, "bar"},
+	} {
+		d := WithMode(d, test.mode)
+		Expect(t, String(d, "string"), test.string)
+		Expect(t, Int(d, "int"), test.int)
+		List(d, "list").Elem(func(d helper.ResourceData) {
+			Expect(t, String(d, "foo"), test.foo)
+			Expect(t, Int(d, "bar"), 2)
+		})
+	}
+}
+
+func TestModeUnchanged(t *testing.T) {
+	d := updateData(t, s, map[string]string{
+		"list.#":     "1",
+		"list.0.foo": "bar",
+	}, map[string]interface{}{
+		"list": []interface{}{
+			map[string]interface{}{"foo": "bar"},
+		},
+	})
+
+	Expect(t, len(List(d, "list").List()), 0)
+	Expect(t, len(List(WithMode(d, Full), "list").List()), 1)
+
+	d2, errs := Collect(WithMode(d, Full))
+	List(d2, "list").Elem(func(d helper.ResourceData) {
+		Expect(t, String(d, "foo"), "bar")
+		Int(d, "foo")
+	})
+	Expect(t, len(*errs), 1)
+}
@@ -0,0 +1,65 @@
+package expand
+
+import (
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestNumeric(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"int": 123,
+	})
+
+	Expect(t, Int32(d, "int"), int32(123))
+	Expect(t, Int64(d, "int"), int64(123))
+	Expect(t, Int64Ptr(d, "int"), int64(123))
+	Expect(t, Uint32(d, "int"), uint32(123))
+	Expect(t, Uint64Ptr(d, "int"), uint64(123))
+	Expect(t, Float32(d, "int"), float32(123))
+	Expect(t, Float64Ptr(d, "int"), float64(123))
+}
+
+func TestNumericConversion(t *testing.T) {
+	d := helper.MapData{
+		"big":      1 << 40,
+		"negative": -1,
+		"float":    1.5,
+		"whole":    2.0,
+		"string":   "9007199254740993",
+		"invalid":  "foo",
+	}
+
+	for _, test := range []struct {
+		fn  func(helper.ResourceData, string) (interface{}, error)
+		key string
+		v   interface{}
+		ok  bool
+	}{
+		{int32E, "big", int32(0), false},
+		{int64E, "big", int64(1 << 40), true},
+		{uint64E, "negative", uint64(0), false},
+		{int64E, "negative", int64(-1), true},
+		{int64E, "float", int64(0), false},
+		{int64E, "whole", int64(2), true},
+		{float32E, "float", float32(1.5), true},
+		{int64E, "string", int64(9007199254740993), true},
+		{uint64E, "string", uint64(9007199254740993), true},
+		{int32E, "string", int32(0), false},
+		{int64E, "invalid", int64(0), false},
+	} {
+		v, err := test.fn(d, test.key)
+		if ok := err == nil; ok != test.ok {
+			t.Errorf("Expected conversion of %q to succeed == %t, err = %v", test.key, test.ok, err)
+		}
+		if err == nil {
+			Expect(t, v, test.v)
+		}
+	}
+}
+
+func int32E(d helper.ResourceData, key string) (interface{}, error)   { return Int32E(d, key) }
+func int64E(d helper.ResourceData, key string) (interface{}, error)   { return Int64E(d, key) }
+func uint64E(d helper.ResourceData, key string) (interface{}, error)  { return Uint64E(d, key) }
+func float32E(d helper.ResourceData, key string) (interface{}, error) { return Float32E(d, key) }
@@ -0,0 +1,285 @@
+package expand
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/alexkappa/terraform-plugin-helper/internal/value"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// A NameFunc maps the name of a Terraform attribute to the name of the API
+// field it represents.
+type NameFunc func(string) string
+
+// CamelCase is a NameFunc mapping snake_case attribute names to camelCase.
+func CamelCase(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// MergePatch returns an RFC 7396 JSON merge patch document holding the changes
+// of the attributes described by m. New resources result in a document holding
+// all configured attributes.
+//
+// Attributes which have been removed are set to nil, which is marshaled as an
+// explicit null. Nested blocks of TypeList with a MaxItems of 1 are treated as
+// objects and patched recursively, as are TypeMap attributes. Other lists and
+// sets are replaced as a whole, as merge patches can't describe changes to
+// arrays.
+//
+// Attribute names are mapped to field names using name, which may be nil.
+func MergePatch(d helper.ResourceData, m map[string]*schema.Schema, name NameFunc) map[string]interface{} {
+	return mergePatch(d, nil, m, nameOrDefault(name))
+}
+
+func mergePatch(d helper.ResourceData, p helper.Path, m map[string]*schema.Schema, name NameFunc) map[string]interface{} {
+	out := make(map[string]interface{})
+	for _, k := range value.SortedKeys(m) {
+		s := m[k]
+		if !helper.Configurable(s) {
+			continue
+		}
+		kp := p.Key(k)
+		key := kp.String()
+		if d.IsNewResource() {
+			if exists(d, key, s) {
+				out[name(k)] = apiValue(d.Get(key), s, name)
+			}
+			continue
+		}
+		if !d.HasChange(key) {
+			continue
+		}
+		o, n := d.GetChange(key)
+		switch {
+		case !exists(d, key, s):
+			out[name(k)] = nil
+		case isEmpty(o):
+			out[name(k)] = apiValue(n, s, name)
+		case isObject(s) && s.Type == schema.TypeList:
+			out[name(k)] = mergePatch(d, kp.Index(0), s.Elem.(*schema.Resource).Schema, name)
+		case s.Type == schema.TypeMap:
+			om, nm := o.(map[string]interface{}), n.(map[string]interface{})
+			patch := make(map[string]interface{})
+			for mk, mv := range nm {
+				if ov, ok := om[mk]; !ok || !reflect.DeepEqual(ov, mv) {
+					patch[mk] = mv
+				}
+			}
+			for mk := range om {
+				if _, ok := nm[mk]; !ok {
+					patch[mk] = nil
+				}
+			}
+			out[name(k)] = patch
+		default:
+			out[name(k)] = apiValue(n, s, name)
+		}
+	}
+	return out
+}
+
+// An Operation is a single RFC 6902 JSON patch operation.
+type Operation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// MarshalJSON omits the value of remove operations.
+func (o Operation) MarshalJSON() ([]byte, error) {
+	type operation Operation
+	if o.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{o.Op, o.Path})
+	}
+	return json.Marshal(operation(o))
+}
+
+// JSONPatch returns the RFC 6902 JSON patch operations describing the changes
+// of the attributes described by m. New resources result in an add operation
+// for each configured attribute.
+//
+// Operations are addressed using JSON pointers derived from the attribute
+// paths. Nested blocks of TypeList with a MaxItems of 1 are treated as objects,
+// while elements of other lists are addressed by their index. Elements added to
+// a list are appended, and elements removed from its end are removed in
+// reverse order so that indexes stay valid. As sets have no stable order, a
+// changed set is replaced as a whole.
+//
+// Attribute names are mapped to field names using name, which may be nil.
+func JSONPatch(d helper.ResourceData, m map[string]*schema.Schema, name NameFunc) []Operation {
+	return jsonPatch(d, nil, "", m, nameOrDefault(name))
+}
+
+func jsonPatch(d helper.ResourceData, p helper.Path, ptr string, m map[string]*schema.Schema, name NameFunc) (ops []Operation) {
+	for _, k := range value.SortedKeys(m) {
+		s := m[k]
+		if !helper.Configurable(s) {
+			continue
+		}
+		kp := p.Key(k)
+		key := kp.String()
+		kptr := ptr + "/" + escapePointer(name(k))
+		if d.IsNewResource() {
+			if exists(d, key, s) {
+				ops = append(ops, Operation{"add", kptr, apiValue(d.Get(key), s, name)})
+			}
+			continue
+		}
+		if !d.HasChange(key) {
+			continue
+		}
+		o, n := d.GetChange(key)
+		switch {
+		case !exists(d, key, s):
+			ops = append(ops, Operation{"remove", kptr, nil})
+		case isEmpty(o):
+			ops = append(ops, Operation{"add", kptr, apiValue(n, s, name)})
+		case isObject(s) && s.Type == schema.TypeList:
+			ops = append(ops, jsonPatch(d, kp.Index(0), kptr, s.Elem.(*schema.Resource).Schema, name)...)
+		case s.Type == schema.TypeList:
+			ops = append(ops, listPatch(d, kp, kptr, s, o.([]interface{}), n.([]interface{}), name)...)
+		case s.Type == schema.TypeMap:
+			om, nm := o.(map[string]interface{}), n.(map[string]interface{})
+			for _, mk := range value.SortedKeys(nm) {
+				mptr := kptr + "/" + escapePointer(mk)
+				if ov, ok := om[mk]; !ok {
+					ops = append(ops, Operation{"add", mptr, nm[mk]})
+				} else if !reflect.DeepEqual(ov, nm[mk]) {
+					ops = append(ops, Operation{"replace", mptr, nm[mk]})
+				}
+			}
+			for _, mk := range value.SortedKeys(om) {
+				if _, ok := nm[mk]; !ok {
+					ops = append(ops, Operation{"remove", kptr + "/" + escapePointer(mk), nil})
+				}
+			}
+		default:
+			ops = append(ops, Operation{"replace", kptr, apiValue(n, s, name)})
+		}
+	}
+	return
+}
+
+func listPatch(d helper.ResourceData, p helper.Path, ptr string, s *schema.Schema, o, n []interface{}, name NameFunc) (ops []Operation) {
+	for i := 0; i < len(o) && i < len(n); i++ {
+		ip := p.Index(i)
+		if !d.HasChange(ip.String()) {
+			continue
+		}
+		iptr := ptr + "/" + strconv.Itoa(i)
+		if r, ok := s.Elem.(*schema.Resource); ok {
+			ops = append(ops, jsonPatch(d, ip, iptr, r.Schema, name)...)
+		} else {
+			ops = append(ops, Operation{"replace", iptr, elemValue(n[i], s.Elem, name)})
+		}
+	}
+	for i := len(o) - 1; i >= len(n); i-- {
+		ops = append(ops, Operation{"remove", ptr + "/" + strconv.Itoa(i), nil})
+	}
+	for i := len(o); i < len(n); i++ {
+		ops = append(ops, Operation{"add", ptr + "/-", elemValue(n[i], s.Elem, name)})
+	}
+	return
+}
+
+// apiValue converts v, described by s, to the representation expected by an
+// API. Sets are converted to slices, and nested blocks of a single element to
+// objects. Empty values of nested blocks are omitted.
+func apiValue(v interface{}, s *schema.Schema, name NameFunc) interface{} {
+	if s.Type != schema.TypeList && s.Type != schema.TypeSet {
+		return v
+	}
+	var items []interface{}
+	if set, ok := v.(*schema.Set); ok {
+		items = set.List()
+	} else {
+		items, _ = v.([]interface{})
+	}
+	out := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		out = append(out, elemValue(item, s.Elem, name))
+	}
+	if isObject(s) {
+		if len(out) == 0 {
+			return nil
+		}
+		return out[0]
+	}
+	return out
+}
+
+func elemValue(v interface{}, elem interface{}, name NameFunc) interface{} {
+	switch elem := elem.(type) {
+	case *schema.Resource:
+		m, _ := v.(map[string]interface{})
+		out := make(map[string]interface{}, len(m))
+		for k, s := range elem.Schema {
+			if fv, ok := m[k]; ok && helper.Configurable(s) && !isEmpty(fv) {
+				out[name(k)] = apiValue(fv, s, name)
+			}
+		}
+		return out
+	case *schema.Schema:
+		return apiValue(v, elem, name)
+	}
+	return v
+}
+
+// exists reports whether the new value of key is set. Strings and collections
+// must be non-empty, while other primitives must have been set explicitly.
+func exists(d helper.ResourceData, key string, s *schema.Schema) bool {
+	switch s.Type {
+	case schema.TypeBool, schema.TypeInt, schema.TypeFloat:
+		_, ok := d.GetOkExists(key)
+		return ok
+	}
+	_, ok := d.GetOk(key)
+	return ok
+}
+
+func isEmpty(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	case *schema.Set:
+		return v.Len() == 0
+	}
+	return false
+}
+
+// isObject reports whether s describes a nested block holding a single
+// element, which APIs usually represent as an object.
+func isObject(s *schema.Schema) bool {
+	_, ok := s.Elem.(*schema.Resource)
+	return ok && s.MaxItems == 1 && (s.Type == schema.TypeList || s.Type == schema.TypeSet)
+}
+
+func nameOrDefault(name NameFunc) NameFunc {
+	if name == nil {
+		return func(s string) string { return s }
+	}
+	return name
+}
+
+func escapePointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
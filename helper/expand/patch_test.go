@@ -0,0 +1,156 @@
+package expand
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+var patchSchema = map[string]*schema.Schema{
+	"display_name": {Type: schema.TypeString, Optional: true},
+	"description":  {Type: schema.TypeString, Optional: true},
+	"enabled":      {Type: schema.TypeBool, Optional: true},
+	"created_at":   {Type: schema.TypeString, Computed: true},
+	"labels": {
+		Type:     schema.TypeMap,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	},
+	"config": {
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"max_size": {Type: schema.TypeInt, Optional: true},
+				"mode":     {Type: schema.TypeString, Optional: true},
+			},
+		},
+	},
+	"rules": {
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"port": {Type: schema.TypeInt, Optional: true},
+			},
+		},
+	},
+	"tags": {
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	},
+}
+
+var patchState = map[string]string{
+	"display_name":      "foo",
+	"description":       "bar",
+	"enabled":           "true",
+	"labels.%":          "2",
+	"labels.a":          "1",
+	"labels.b":          "2",
+	"config.#":          "1",
+	"config.0.max_size": "1",
+	"config.0.mode":     "fast",
+	"rules.#":           "2",
+	"rules.0.port":      "80",
+	"rules.1.port":      "443",
+	"tags.#":            "1",
+	"tags.389285925":    "x",
+}
+
+var patchConfig = map[string]interface{}{
+	"display_name": "foo",
+	"enabled":      false,
+	"labels":       map[string]interface{}{"a": "1", "b": "3", "c": "4"},
+	"config": []interface{}{
+		map[string]interface{}{"max_size": 2, "mode": "fast"},
+	},
+	"rules": []interface{}{
+		map[string]interface{}{"port": 8080},
+	},
+	"tags": []interface{}{"y"},
+}
+
+func TestCamelCase(t *testing.T) {
+	Expect(t, CamelCase("display_name"), "displayName")
+	Expect(t, CamelCase("max_size_in_gb"), "maxSizeInGb")
+	Expect(t, CamelCase("id"), "id")
+}
+
+func TestMergePatch(t *testing.T) {
+	d := updateData(t, patchSchema, patchState, patchConfig)
+
+	patch := MergePatch(d, patchSchema, CamelCase)
+
+	b, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Expect(t, string(b), `{"config":{"maxSize":2},"description":null,"enabled":false,`+
+		`"labels":{"b":"3","c":"4"},"rules":[{"port":8080}],"tags":["y"]}`)
+}
+
+func TestMergePatchNew(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, patchSchema, patchConfig)
+
+	patch := MergePatch(d, patchSchema, nil)
+
+	Expect(t, patch["display_name"], "foo")
+	Expect(t, patch["config"], map[string]interface{}{"max_size": 2, "mode": "fast"})
+	Expect(t, patch["tags"], []interface{}{"y"})
+	if _, ok := patch["created_at"]; ok {
+		t.Error("Expected computed attributes to be omitted")
+	}
+}
+
+func TestJSONPatch(t *testing.T) {
+	d := updateData(t, patchSchema, patchState, patchConfig)
+
+	ops := JSONPatch(d, patchSchema, CamelCase)
+
+	b, err := json.Marshal(ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Expect(t, string(b), `[`+
+		`{"op":"replace","path":"/config/maxSize","value":2},`+
+		`{"op":"remove","path":"/description"},`+
+		`{"op":"replace","path":"/enabled","value":false},`+
+		`{"op":"replace","path":"/labels/b","value":"3"},`+
+		`{"op":"add","path":"/labels/c","value":"4"},`+
+		`{"op":"replace","path":"/rules/0/port","value":8080},`+
+		`{"op":"remove","path":"/rules/1"},`+
+		`{"op":"replace","path":"/tags","value":["y"]}`+
+		`]`)
+}
+
+func TestJSONPatchNew(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, patchSchema, map[string]interface{}{
+		"display_name": "a/b",
+	})
+	Expect(t, JSONPatch(d, patchSchema, nil), []Operation{
+		{"add", "/display_name", "a/b"},
+	})
+	Expect(t, escapePointer("a/b~c"), "a~1b~0c")
+}
+
+func TestPatchChangeData(t *testing.T) {
+	d := helper.NewChangeData(
+		map[string]interface{}{"display_name": "foo", "enabled": true},
+		map[string]interface{}{"display_name": "foo", "enabled": false},
+		false,
+	)
+
+	b, err := json.Marshal(MergePatch(d, patchSchema, CamelCase))
+	if err != nil {
+		t.Fatal(err)
+	}
+	Expect(t, string(b), `{"enabled":false}`)
+	Expect(t, JSONPatch(d, patchSchema, CamelCase), []Operation{
+		{"replace", "/enabled", false},
+	})
+}
@@ -0,0 +1,54 @@
+package expand
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+)
+
+func TestRecorder(t *testing.T) {
+	r := helper.NewRecorder(helper.NewChangeData(nil, map[string]interface{}{
+		"list": []interface{}{
+			map[string]interface{}{"foo": "bar"},
+		},
+	}, true))
+
+	List(r, "list").Elem(func(d helper.ResourceData) {
+		String(d, "foo")
+	})
+
+	r.ExpectRead(t, "list", "list.foo")
+
+	calls := r.Calls()
+	last := calls[len(calls)-1]
+	Expect(t, last.Path.String(), "list.0.foo")
+	Expect(t, filepath.Base(last.File), "recorder_test.go")
+}
+
+func TestRecorderCollect(t *testing.T) {
+	d, errs := Collect(helper.NewChangeData(nil, map[string]interface{}{
+		"string": "hello!",
+		"list": []interface{}{
+			map[string]interface{}{"foo": "bar"},
+		},
+	}, true))
+	r := helper.NewRecorder(d)
+
+	Int(r, "string")
+	List(r, "list").Elem(func(d helper.ResourceData) {
+		Int(d, "foo")
+	})
+
+	Expect(t, len(*errs), 2)
+	Expect(t, (*errs)[1].(*TypeError).Path.String(), "list.0.foo")
+	r.ExpectRead(t, "string", "list", "list.foo")
+}
+
+func TestRecorderWithMode(t *testing.T) {
+	o := map[string]interface{}{"string": "hello!"}
+	r := helper.NewRecorder(WithMode(helper.NewChangeData(o, o, false), Full))
+
+	Expect(t, String(r, "string"), "hello!")
+	r.ExpectRead(t, "string")
+}
@@ -0,0 +1,105 @@
+package expand
+
+import (
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataSet(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"list": []interface{}{
+			map[string]interface{}{"foo": "a", "bar": 1},
+			map[string]interface{}{"foo": "b", "bar": 2},
+		},
+		"set": []interface{}{
+			map[string]interface{}{"foo": "c", "bar": 3},
+		},
+	})
+
+	List(d, "list").Elem(func(d helper.ResourceData) {
+		if err := d.Set("foo", String(d, "foo")+"!"); err != nil {
+			t.Fatal(err)
+		}
+	})
+	Expect(t, d.Get("list.0.foo"), "a!")
+	Expect(t, d.Get("list.1.foo"), "b!")
+	Expect(t, d.Get("list.1.bar"), 2)
+
+	_, ok := d.GetOk("foo")
+	Expect(t, ok, false)
+
+	Set(d, "set").Elem(func(d helper.ResourceData) {
+		if err := d.Set("bar", 4); err != nil {
+			t.Fatal(err)
+		}
+	})
+	Expect(t, d.Get("set").(*schema.Set).List(), []interface{}{
+		map[string]interface{}{"foo": "c", "bar": 4},
+	})
+}
+
+func TestDataSetInvalid(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"list": []interface{}{
+			map[string]interface{}{"foo": "a"},
+		},
+	})
+	if err := dataAtKey("5", dataAtKey("list", d)).Set("foo", "b"); err == nil {
+		t.Error("Expected an error setting an element out of range")
+	}
+	if err := dataAtKey("list", d).Set("foo", "b"); err == nil {
+		t.Error("Expected an error setting a key on a list")
+	}
+}
+
+func TestDataPath(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"list": []interface{}{
+			map[string]interface{}{"foo": "a"},
+		},
+		"set": []interface{}{
+			map[string]interface{}{"foo": "b"},
+		},
+	})
+
+	Expect(t, len(helper.PathOf(d)), 0)
+
+	List(d, "list").Elem(func(d helper.ResourceData) {
+		Expect(t, helper.PathOf(d), helper.Path{helper.Key("list"), helper.Index(0)})
+	})
+	Set(d, "set").Elem(func(d helper.ResourceData) {
+		p := helper.PathOf(d)
+		Expect(t, len(p), 2)
+		if _, ok := p[1].(helper.SetHash); !ok {
+			t.Errorf("Expected a set element to be addressed by hash, instead it was %T", p[1])
+		}
+	})
+
+	var code helper.SetHash
+	Set(d, "set").Elem(func(d helper.ResourceData) {
+		code = helper.PathOf(d)[1].(helper.SetHash)
+	})
+	_, err := IntE(d, "set."+code.String()+".foo")
+	p := err.(*TypeError).Path
+	Expect(t, p, helper.Path{helper.Key("set"), code, helper.Key("foo")})
+	_, ok := p.Cty()
+	Expect(t, ok, false)
+}
+
+func TestMapDataElem(t *testing.T) {
+	d := helper.MapData{
+		"list": []interface{}{
+			map[string]interface{}{"foo": "a", "bar": 1},
+		},
+	}
+	List(d, "list").Elem(func(d helper.ResourceData) {
+		Expect(t, String(d, "foo"), "a")
+		Expect(t, Int64(d, "bar"), int64(1))
+		if err := d.Set("foo", "b"); err != nil {
+			t.Fatal(err)
+		}
+	})
+	Expect(t, d.Get("list.0.foo"), "b")
+}
@@ -0,0 +1,311 @@
+package expand
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/alexkappa/terraform-plugin-helper/internal/tag"
+	"github.com/alexkappa/terraform-plugin-helper/internal/value"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// Struct expands the data held by d into the struct pointed to by dst.
+//
+// Struct fields are mapped to attributes using the `tf:"name"` struct tag.
+// Fields without a tag or tagged with `tf:"-"` are ignored. Fields of struct
+// type, or pointers to them, are expanded from the first element of a list or
+// set, while slices of structs are expanded from all of its elements. Nested
+// structs are read using the same prefixed data passed to Iterator.Elem.
+//
+// The operation
+//
+//	Set(d, "mounts").Elem(func(d helper.ResourceData) {
+//		mounts = append(mounts, &Mount{
+//			Target: String(d, "target"),
+//			Source: StringPtr(d, "source"),
+//		})
+//	})
+//
+// can be expressed as
+//
+//	var v struct {
+//		Mounts []*Mount `tf:"mounts"`
+//	}
+//	err := Struct(d, &v)
+//
+// given Mount is annotated with `tf:"target"` and `tf:"source"` tags.
+//
+// If a value can't be converted to the type of its field, a *TypeError holding
+// the full path of the attribute is returned.
+func Struct(d helper.ResourceData, dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expand: Struct requires a non-nil pointer to a struct, got %T", dst)
+	}
+	return expandStruct(d, rv.Elem())
+}
+
+func expandStruct(d helper.ResourceData, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		name, _, ok := tag.Parse(f)
+		if !ok {
+			if f.Anonymous && f.Type.Kind() == reflect.Struct {
+				if err := expandStruct(d, rv.Field(i)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		if err := expandField(d, name, rv.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func expandField(d helper.ResourceData, key string, fv reflect.Value) error {
+	v, ok := get(d, key)
+	if !ok {
+		return nil
+	}
+	t := fv.Type()
+	switch {
+	case indirect(t).Kind() == reflect.Struct:
+		return expandBlock(d, key, v, fv)
+	case t.Kind() == reflect.Slice && indirect(t.Elem()).Kind() == reflect.Struct:
+		return expandBlocks(d, key, v, fv)
+	}
+	return assign(path(d, key), fv, v)
+}
+
+// expandBlock expands the single element of a list or set into fv.
+func expandBlock(d helper.ResourceData, key string, v interface{}, fv reflect.Value) error {
+	it, ok := iterator(d, key, v)
+	if !ok || len(it.List()) > 1 {
+		return &TypeError{path(d, key), v, fv.Type()}
+	}
+	var err error
+	it.Elem(func(d helper.ResourceData) {
+		elem := reflect.New(indirect(fv.Type())).Elem()
+		if err = expandStruct(d, elem); err == nil {
+			setIndirect(fv, elem)
+		}
+	})
+	return err
+}
+
+// expandBlocks expands each element of a list or set into the slice fv.
+func expandBlocks(d helper.ResourceData, key string, v interface{}, fv reflect.Value) error {
+	it, ok := iterator(d, key, v)
+	if !ok {
+		return &TypeError{path(d, key), v, fv.Type()}
+	}
+	var err error
+	s := reflect.MakeSlice(fv.Type(), 0, len(it.List()))
+	it.Elem(func(d helper.ResourceData) {
+		if err != nil {
+			return
+		}
+		elem := reflect.New(fv.Type().Elem()).Elem()
+		block := reflect.New(indirect(elem.Type())).Elem()
+		if err = expandStruct(d, block); err == nil {
+			setIndirect(elem, block)
+			s = reflect.Append(s, elem)
+		}
+	})
+	if err == nil {
+		fv.Set(s)
+	}
+	return err
+}
+
+// iterator returns an Iterator over v, which is expected to be held by key.
+func iterator(d helper.ResourceData, key string, v interface{}) (Iterator, bool) {
+	switch v := v.(type) {
+	case []interface{}:
+		return &list{dataAtKey(key, d), v}, true
+	case *schema.Set:
+		return &set{dataAtKey(key, d), v}, true
+	}
+	return nil, false
+}
+
+// assign converts v to the type of fv and sets it. Pointers are allocated as
+// needed, and slices and maps are converted element by element.
+func assign(p helper.Path, fv reflect.Value, v interface{}) error {
+	if v == nil {
+		return nil
+	}
+	t := fv.Type()
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(t.Elem())
+		if err := assign(p, elem.Elem(), v); err != nil {
+			return err
+		}
+		fv.Set(elem)
+	case reflect.Slice:
+		var (
+			items  []interface{}
+			pathOf = p.Index
+		)
+		switch v := v.(type) {
+		case []interface{}:
+			items = v
+		case *schema.Set:
+			items = v.List()
+			pathOf = func(i int) helper.Path { return p.SetHash(value.HashCode(v, items[i])) }
+		default:
+			return &TypeError{p, v, t}
+		}
+		s := reflect.MakeSlice(t, len(items), len(items))
+		for i, item := range items {
+			if err := assign(pathOf(i), s.Index(i), item); err != nil {
+				return err
+			}
+		}
+		fv.Set(s)
+	case reflect.Map:
+		m, ok := v.(map[string]interface{})
+		if !ok || t.Key().Kind() != reflect.String {
+			return &TypeError{p, v, t}
+		}
+		out := reflect.MakeMapWithSize(t, len(m))
+		for k, item := range m {
+			elem := reflect.New(t.Elem()).Elem()
+			if err := assign(p.MapKey(k), elem, item); err != nil {
+				return err
+			}
+			out.SetMapIndex(reflect.ValueOf(k).Convert(t.Key()), elem)
+		}
+		fv.Set(out)
+	default:
+		rv, ok := convert(reflect.ValueOf(v), t)
+		if !ok {
+			return &TypeError{p, v, t}
+		}
+		fv.Set(rv)
+	}
+	return nil
+}
+
+// convert converts v to type t. Numbers are converted between kinds as long as
+// the value does not overflow t, and strings holding numbers too large for
+// Terraform's number types are parsed. Other values must be of the same kind.
+func convert(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if v.Type().AssignableTo(t) {
+		return v, true
+	}
+	out := reflect.New(t).Elem()
+	switch {
+	case v.Kind() == reflect.String && isNumber(t.Kind()):
+		return parseNumber(out, v.String())
+	case isInt(v.Kind()) && isNumber(t.Kind()):
+		return setNumber(out, v.Int(), float64(v.Int()))
+	case isUint(v.Kind()) && isUint(t.Kind()):
+		if out.OverflowUint(v.Uint()) {
+			return out, false
+		}
+		out.SetUint(v.Uint())
+		return out, true
+	case isUint(v.Kind()) && isNumber(t.Kind()):
+		if v.Uint() > 1<<63-1 {
+			return out, false
+		}
+		return setNumber(out, int64(v.Uint()), float64(v.Uint()))
+	case isFloat(v.Kind()) && isNumber(t.Kind()):
+		f := v.Float()
+		if !isFloat(t.Kind()) && f != float64(int64(f)) {
+			return out, false
+		}
+		return setNumber(out, int64(f), f)
+	case v.Kind() == t.Kind() && v.Type().ConvertibleTo(t):
+		return v.Convert(t), true
+	}
+	return out, false
+}
+
+func setNumber(out reflect.Value, i int64, f float64) (reflect.Value, bool) {
+	switch {
+	case isInt(out.Kind()):
+		if out.OverflowInt(i) {
+			return out, false
+		}
+		out.SetInt(i)
+	case isUint(out.Kind()):
+		if i < 0 || out.OverflowUint(uint64(i)) {
+			return out, false
+		}
+		out.SetUint(uint64(i))
+	case isFloat(out.Kind()):
+		if out.OverflowFloat(f) {
+			return out, false
+		}
+		out.SetFloat(f)
+	}
+	return out, true
+}
+
+func parseNumber(out reflect.Value, s string) (reflect.Value, bool) {
+	switch {
+	case isInt(out.Kind()):
+		i, err := strconv.ParseInt(s, 10, out.Type().Bits())
+		if err != nil {
+			return out, false
+		}
+		out.SetInt(i)
+	case isUint(out.Kind()):
+		u, err := strconv.ParseUint(s, 10, out.Type().Bits())
+		if err != nil {
+			return out, false
+		}
+		out.SetUint(u)
+	case isFloat(out.Kind()):
+		f, err := strconv.ParseFloat(s, out.Type().Bits())
+		if err != nil {
+			return out, false
+		}
+		out.SetFloat(f)
+	}
+	return out, true
+}
+
+func isInt(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUint(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isFloat(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+func isNumber(k reflect.Kind) bool {
+	return isInt(k) || isUint(k) || isFloat(k)
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+// setIndirect sets fv to v, allocating a pointer if fv is one.
+func setIndirect(fv, v reflect.Value) {
+	if fv.Kind() == reflect.Ptr {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		v = p
+	}
+	fv.Set(v)
+}
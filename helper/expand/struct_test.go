@@ -0,0 +1,95 @@
+package expand
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+type structElem struct {
+	Foo string `tf:"foo"`
+	Bar *int64 `tf:"bar"`
+}
+
+type structTest struct {
+	String  *string           `tf:"string"`
+	Int     int32             `tf:"int"`
+	Bool    bool              `tf:"bool"`
+	Map     map[string]string `tf:"map"`
+	List    *structElem       `tf:"list"`
+	Set     []structElem      `tf:"set"`
+	Ignored string            `tf:"-"`
+}
+
+func TestStruct(t *testing.T) {
+
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"string": "hello!",
+		"int":    123,
+		"bool":   true,
+		"map":    map[string]interface{}{"foo": "bar"},
+		"list": []interface{}{
+			map[string]interface{}{
+				"foo": "bar",
+				"bar": 123,
+			},
+		},
+		"set": []interface{}{
+			map[string]interface{}{
+				"foo": "baz",
+				"bar": 456,
+			},
+		},
+	})
+
+	var v structTest
+	if err := Struct(d, &v); err != nil {
+		t.Fatal(err)
+	}
+
+	Expect(t, v.String, "hello!")
+	Expect(t, v.Int, int32(123))
+	Expect(t, v.Bool, true)
+	Expect(t, v.Map, map[string]string{"foo": "bar"})
+	Expect(t, v.List.Foo, "bar")
+	Expect(t, v.List.Bar, int64(123))
+	Expect(t, len(v.Set), 1)
+	Expect(t, v.Set[0].Foo, "baz")
+	Expect(t, v.Set[0].Bar, int64(456))
+}
+
+func TestStructTypeError(t *testing.T) {
+
+	d := schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"list": []interface{}{
+			map[string]interface{}{
+				"foo": "bar",
+				"bar": 123,
+			},
+		},
+	})
+
+	var v struct {
+		List []struct {
+			Bar string `tf:"bar"`
+		} `tf:"list"`
+	}
+
+	err := Struct(d, &v)
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	te, ok := err.(*TypeError)
+	if !ok {
+		t.Fatalf("Expected a *TypeError, instead it was %T", err)
+	}
+	Expect(t, te.Path.String(), "list.0.bar")
+	Expect(t, te.Value, 123)
+}
+
+func TestStructInvalid(t *testing.T) {
+	var v structTest
+	if err := Struct(nil, v); err == nil {
+		t.Error("Expected an error when dst is not a pointer")
+	}
+}
@@ -0,0 +1,130 @@
+package flatten
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/alexkappa/terraform-plugin-helper/internal/value"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ToCty converts flattened, a value in Terraform's internal representation such
+// as the result of Flatten or a helper.MapData, to a value of the type
+// impliedType, such as the implied type of a resource's schema.
+//
+// A nil value, including attributes missing from a map, is converted to a null
+// value, while empty lists, sets and maps are converted to empty values. Nested
+// blocks, which are flattened to a list of a single map, may be converted to an
+// object.
+func ToCty(flattened interface{}, impliedType cty.Type) (cty.Value, error) {
+	return toCty(nil, flattened, impliedType)
+}
+
+func toCty(p helper.Path, v interface{}, t cty.Type) (cty.Value, error) {
+	v = value.Indirect(v)
+	if v == nil {
+		return cty.NullVal(t), nil
+	}
+	switch {
+	case t.IsObjectType():
+		if l, ok := v.([]interface{}); ok && len(l) <= 1 {
+			if len(l) == 0 {
+				return cty.NullVal(t), nil
+			}
+			v = value.Indirect(l[0])
+		}
+		m, ok := value.Map(v)
+		if !ok {
+			return cty.NilVal, ctyError(p, v, t)
+		}
+		var unexpected []string
+		for k := range m {
+			if !t.HasAttribute(k) {
+				unexpected = append(unexpected, k)
+			}
+		}
+		if len(unexpected) > 0 {
+			sort.Strings(unexpected)
+			return cty.NilVal, fmt.Errorf("flatten: unexpected attribute %q", append(p, helper.Key(unexpected[0])).String())
+		}
+		attrs := make(map[string]cty.Value, len(t.AttributeTypes()))
+		for k, at := range t.AttributeTypes() {
+			e, err := toCty(append(p, helper.Key(k)), m[k], at)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			attrs[k] = e
+		}
+		return cty.ObjectVal(attrs), nil
+	case t.IsMapType():
+		m, ok := value.Map(v)
+		if !ok {
+			return cty.NilVal, ctyError(p, v, t)
+		}
+		if len(m) == 0 {
+			return cty.MapValEmpty(t.ElementType()), nil
+		}
+		elems := make(map[string]cty.Value, len(m))
+		for k, item := range m {
+			e, err := toCty(append(p, helper.MapKey(k)), item, t.ElementType())
+			if err != nil {
+				return cty.NilVal, err
+			}
+			elems[k] = e
+		}
+		return cty.MapVal(elems), nil
+	case t.IsListType(), t.IsSetType(), t.IsTupleType():
+		l, ok := value.List(v)
+		if !ok {
+			return cty.NilVal, ctyError(p, v, t)
+		}
+		if t.IsTupleType() && len(l) != len(t.TupleElementTypes()) {
+			return cty.NilVal, ctyError(p, v, t)
+		}
+		elems := make([]cty.Value, len(l))
+		for i, item := range l {
+			var et cty.Type
+			if t.IsTupleType() {
+				et = t.TupleElementTypes()[i]
+			} else {
+				et = t.ElementType()
+			}
+			e, err := toCty(append(p, helper.Index(i)), item, et)
+			if err != nil {
+				return cty.NilVal, err
+			}
+			elems[i] = e
+		}
+		switch {
+		case t.IsTupleType():
+			return cty.TupleVal(elems), nil
+		case len(elems) == 0 && t.IsListType():
+			return cty.ListValEmpty(t.ElementType()), nil
+		case len(elems) == 0:
+			return cty.SetValEmpty(t.ElementType()), nil
+		case t.IsListType():
+			return cty.ListVal(elems), nil
+		}
+		return cty.SetVal(elems), nil
+	case t == cty.String:
+		if s, ok := v.(string); ok {
+			return cty.StringVal(s), nil
+		}
+	case t == cty.Bool:
+		if b, ok := v.(bool); ok {
+			return cty.BoolVal(b), nil
+		}
+	case t == cty.Number:
+		if f, ok := value.BigFloat(v); ok {
+			return cty.NumberVal(f), nil
+		}
+	default:
+		return cty.NilVal, fmt.Errorf("flatten: unsupported type %s at %q", t.FriendlyName(), p.String())
+	}
+	return cty.NilVal, ctyError(p, v, t)
+}
+
+func ctyError(p helper.Path, v interface{}, t cty.Type) error {
+	return fmt.Errorf("flatten: cannot convert %T to %s at %q", v, t.FriendlyName(), p.String())
+}
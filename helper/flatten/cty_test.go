@@ -0,0 +1,96 @@
+package flatten
+
+import (
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/alexkappa/terraform-plugin-helper/internal/testing/expect"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var ctyResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name":   {Type: schema.TypeString, Required: true},
+		"size":   {Type: schema.TypeInt, Optional: true},
+		"tags":   {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"labels": {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"mount": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"target": {Type: schema.TypeString, Required: true},
+				},
+			},
+		},
+	},
+}
+
+func TestToCty(t *testing.T) {
+	typ := ctyResource.CoreConfigSchema().ImpliedType()
+
+	d := make(helper.MapData)
+	d.Set("name", "foo")
+	d.Set("size", 3)
+	d.Set("tags", []interface{}{})
+	d.Set("mount", Func(func(d helper.ResourceData) {
+		d.Set("target", "/mnt")
+	}))
+
+	v, err := ToCty(d, typ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, v.GetAttr("name"), cty.StringVal("foo"))
+	expect.Expect(t, v.GetAttr("size").Equals(cty.NumberIntVal(3)), cty.True)
+	expect.Expect(t, v.GetAttr("tags"), cty.SetValEmpty(cty.String))
+	expect.Expect(t, v.GetAttr("labels"), cty.NullVal(cty.Map(cty.String)))
+	expect.Expect(t, v.GetAttr("mount").Index(cty.NumberIntVal(0)).GetAttr("target"), cty.StringVal("/mnt"))
+	expect.Expect(t, v.GetAttr("id"), cty.NullVal(cty.String))
+
+	v, err = ToCty(nil, typ)
+	expect.Expect(t, err, nil)
+	expect.Expect(t, v.IsNull(), true)
+
+	obj := cty.Object(map[string]cty.Type{"target": cty.String})
+	v, err = ToCty([]interface{}{}, obj)
+	expect.Expect(t, err, nil)
+	expect.Expect(t, v, cty.NullVal(obj))
+
+	for _, test := range []struct {
+		v   interface{}
+		err string
+	}{
+		{map[string]interface{}{"name": 1}, `flatten: cannot convert int to string at "name"`},
+		{map[string]interface{}{"mount": []interface{}{map[string]interface{}{"target": true}}}, `flatten: cannot convert bool to string at "mount.0.target"`},
+		{map[string]interface{}{"foo": "bar"}, `flatten: unexpected attribute "foo"`},
+	} {
+		_, err := ToCty(test.v, typ)
+		if err == nil {
+			t.Errorf("Expected an error converting %v", test.v)
+			continue
+		}
+		expect.Expect(t, err.Error(), test.err)
+	}
+}
+
+func TestToCtyFromCty(t *testing.T) {
+	typ := ctyResource.CoreConfigSchema().ImpliedType()
+	in := map[string]interface{}{
+		"name":   "foo",
+		"tags":   schema.NewSet(schema.HashString, []interface{}{"a", "b"}),
+		"labels": map[string]interface{}{"env": "prod"},
+	}
+	v, err := ToCty(in, typ)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := helper.FromCty(v, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, d.Get("name"), "foo")
+	expect.Expect(t, d.Get("labels"), map[string]interface{}{"env": "prod"})
+	expect.Expect(t, d.Get("tags.#"), 2)
+}
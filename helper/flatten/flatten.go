@@ -2,7 +2,10 @@
 // structures with terraform providers.
 package flatten
 
-import "github.com/alexkappa/terraform-plugin-helper/helper"
+import (
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
 
 // A Flattener is used to flatten data into Terraform's internal representation.
 type Flattener interface {
@@ -27,6 +30,18 @@ func Flatten(f Flattener) []interface{} {
 	return []interface{}{map[string]interface{}(d)}
 }
 
+// FlattenSchema behaves like Flatten, except that values are validated against
+// the schema m of the nested block as they are set. The first value which
+// doesn't conform to m is reported as a *helper.SchemaError.
+func FlattenSchema(m map[string]*schema.Schema, f Flattener) ([]interface{}, error) {
+	d := helper.NewMapData(m)
+	f.Flatten(d)
+	if err := d.Err(); err != nil {
+		return nil, err
+	}
+	return []interface{}{map[string]interface{}(d.MapData)}, nil
+}
+
 // Func executes the provided function and wraps the result in a []interface{}
 // which is used by Terraform list or set types.
 func Func(fn func(helper.ResourceData)) []interface{} {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/alexkappa/terraform-plugin-helper/helper"
 	"github.com/alexkappa/terraform-plugin-helper/internal/testing/expect"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
 // flattener satisfies the Flattener interface and can be used with the packages
@@ -22,6 +23,22 @@ func TestFlatten(t *testing.T) {
 	t.Logf("%v", flat) // [map[foo:bar]]
 }
 
+func TestFlattenSchema(t *testing.T) {
+	m := map[string]*schema.Schema{
+		"foo": {Type: schema.TypeString, Optional: true},
+	}
+	flat, err := FlattenSchema(m, flattener{"bar"})
+	expect.Expect(t, err, nil)
+	expect.Expect(t, flat[0].(map[string]interface{})["foo"], "bar")
+
+	_, err = FlattenSchema(m, FlattenerFunc(func(d helper.ResourceData) {
+		d.Set("foo", 1)
+	}))
+	if _, ok := err.(*helper.SchemaError); !ok {
+		t.Errorf("expected a *helper.SchemaError, got %v", err)
+	}
+}
+
 func TestFlattenFunc(t *testing.T) {
 	flat := Func(func(d helper.ResourceData) {
 		d.Set("foo", "bar")
@@ -0,0 +1,27 @@
+package flatten
+
+import "github.com/alexkappa/terraform-plugin-helper/helper"
+
+// Slice flattens each element of in by calling its Flatten method and returns
+// the results as a []interface{} which is used by Terraform list or set types.
+//
+// Unlike FlattenList, it does not require the slice to be wrapped in a type
+// implementing List.
+func Slice[T Flattener](in []T) []interface{} {
+	out := make([]interface{}, 0, len(in))
+	for _, f := range in {
+		out = append(out, Flatten(f)...)
+	}
+	return out
+}
+
+// SliceFunc flattens each element of in by calling fn with it and returns the
+// results as a []interface{} which is used by Terraform list or set types.
+func SliceFunc[T any](in []T, fn func(T, helper.ResourceData)) []interface{} {
+	out := make([]interface{}, 0, len(in))
+	for _, v := range in {
+		v := v
+		out = append(out, Func(func(d helper.ResourceData) { fn(v, d) })...)
+	}
+	return out
+}
@@ -0,0 +1,28 @@
+package flatten
+
+import (
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/alexkappa/terraform-plugin-helper/internal/testing/expect"
+)
+
+func TestSlice(t *testing.T) {
+	flat := Slice([]flattener{{"bar"}, {"baz"}})
+	expect.Expect(t, flat, []interface{}{
+		map[string]interface{}{"foo": "bar"},
+		map[string]interface{}{"foo": "baz"},
+	})
+	t.Logf("%v", flat) // [map[foo:bar] map[foo:baz]]
+}
+
+func TestSliceFunc(t *testing.T) {
+	flat := SliceFunc([]item{{"bar"}, {"baz"}}, func(i item, d helper.ResourceData) {
+		d.Set("name", i.name)
+	})
+	expect.Expect(t, flat, []interface{}{
+		map[string]interface{}{"name": "bar"},
+		map[string]interface{}{"name": "baz"},
+	})
+	t.Logf("%v", flat) // [map[name:bar] map[name:baz]]
+}
@@ -0,0 +1,131 @@
+package flatten
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/alexkappa/terraform-plugin-helper/internal/tag"
+)
+
+// Struct flattens v, a struct or a pointer to one, and wraps the result in a
+// []interface{} which is used by Terraform list or set types. It is the
+// reflective counterpart of Flatten.
+//
+// Struct fields are mapped to attributes using the `tf:"name"` struct tag.
+// Fields without a tag or tagged with `tf:"-"` are ignored. Pointers are
+// dereferenced and nil pointers skipped, nested structs are flattened into
+// single element lists and slices of structs into lists of maps. Fields tagged
+// with `tf:"name,omitempty"` are skipped if they hold their zero value.
+//
+// If v is nil or not a struct, Struct returns nil.
+func Struct(v interface{}) []interface{} {
+	rv, ok := structValue(v)
+	if !ok {
+		return nil
+	}
+	return []interface{}{flattenStruct(rv)}
+}
+
+// Into flattens the fields of v, a struct or a pointer to one, setting each of
+// them on d. The fields are mapped to attributes the same way as they are with
+// Struct.
+//
+// The first error returned by d.Set is returned.
+func Into(d helper.ResourceData, v interface{}) error {
+	rv, ok := structValue(v)
+	if !ok {
+		return fmt.Errorf("flatten: Into requires a struct or a pointer to one, got %T", v)
+	}
+	var err error
+	fields(rv, func(key string, value interface{}) {
+		if err == nil {
+			err = d.Set(key, value)
+		}
+	})
+	return err
+}
+
+func structValue(v interface{}) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return rv, false
+		}
+		rv = rv.Elem()
+	}
+	return rv, rv.Kind() == reflect.Struct
+}
+
+// fields calls fn with the attribute name and flattened value of each tagged
+// field of the struct rv.
+func fields(rv reflect.Value, fn func(key string, value interface{})) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		name, opts, ok := tag.Parse(f)
+		if !ok {
+			if f.Anonymous && f.Type.Kind() == reflect.Struct {
+				fields(rv.Field(i), fn)
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		fv := rv.Field(i)
+		if opts.Contains("omitempty") && fv.IsZero() {
+			continue
+		}
+		if fv.Kind() == reflect.Struct || fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct {
+			if sv, ok := structValue(fv.Interface()); ok {
+				fn(name, []interface{}{flattenStruct(sv)})
+			}
+			continue
+		}
+		if value, ok := flattenValue(fv); ok {
+			fn(name, value)
+		}
+	}
+}
+
+func flattenStruct(rv reflect.Value) map[string]interface{} {
+	m := make(helper.MapData)
+	fields(rv, func(key string, value interface{}) {
+		m.Set(key, value)
+	})
+	return map[string]interface{}(m)
+}
+
+// flattenValue converts rv into Terraform's internal representation. Structs
+// are converted to maps, slices to []interface{} and maps to
+// map[string]interface{}. The boolean result is false for nil pointers.
+func flattenValue(rv reflect.Value) (interface{}, bool) {
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil, false
+		}
+		return flattenValue(rv.Elem())
+	case reflect.Struct:
+		return flattenStruct(rv), true
+	case reflect.Slice, reflect.Array:
+		out := make([]interface{}, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			if v, ok := flattenValue(rv.Index(i)); ok {
+				out = append(out, v)
+			}
+		}
+		return out, true
+	case reflect.Map:
+		out := make(map[string]interface{}, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			if v, ok := flattenValue(iter.Value()); ok {
+				out[fmt.Sprint(iter.Key().Interface())] = v
+			}
+		}
+		return out, true
+	}
+	return rv.Interface(), true
+}
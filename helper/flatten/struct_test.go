@@ -0,0 +1,82 @@
+package flatten
+
+import (
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/alexkappa/terraform-plugin-helper/internal/testing/expect"
+)
+
+type mount struct {
+	Target   string            `tf:"target"`
+	Source   *string           `tf:"source"`
+	ReadOnly bool              `tf:"read_only,omitempty"`
+	Labels   map[string]string `tf:"labels,omitempty"`
+	Options  *mountOptions     `tf:"options"`
+}
+
+type mountOptions struct {
+	NoCopy bool `tf:"no_copy"`
+}
+
+type containerSpec struct {
+	Image   string   `tf:"image"`
+	Mounts  []*mount `tf:"mounts"`
+	Ignored string
+}
+
+func TestStruct(t *testing.T) {
+	source := "volume"
+	flat := Struct(&containerSpec{
+		Image: "nginx",
+		Mounts: []*mount{
+			{Target: "/a", Source: &source, Options: &mountOptions{true}},
+			{Target: "/b", ReadOnly: true, Labels: map[string]string{"foo": "bar"}},
+		},
+		Ignored: "ignored",
+	})
+	expect.Expect(t, flat, []interface{}{
+		map[string]interface{}{
+			"image": "nginx",
+			"mounts": []interface{}{
+				map[string]interface{}{
+					"target": "/a",
+					"source": "volume",
+					"options": []interface{}{
+						map[string]interface{}{"no_copy": true},
+					},
+				},
+				map[string]interface{}{
+					"target":    "/b",
+					"read_only": true,
+					"labels":    map[string]interface{}{"foo": "bar"},
+				},
+			},
+		},
+	})
+	t.Logf("%v", flat)
+}
+
+func TestStructNil(t *testing.T) {
+	var spec *containerSpec
+	expect.Expect(t, Struct(spec) == nil, true)
+	expect.Expect(t, Struct("foo") == nil, true)
+}
+
+func TestInto(t *testing.T) {
+	d := make(helper.MapData)
+	err := Into(d, containerSpec{
+		Image:  "nginx",
+		Mounts: []*mount{{Target: "/a"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect.Expect(t, d.Get("image"), "nginx")
+	expect.Expect(t, d.Get("mounts"), []interface{}{
+		map[string]interface{}{"target": "/a"},
+	})
+	if err := Into(d, "foo"); err == nil {
+		t.Error("Expected an error when v is not a struct")
+	}
+}
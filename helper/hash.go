@@ -0,0 +1,57 @@
+package helper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// HashValue is a schema.SchemaSetFunc hashing values held in Terraform's
+// internal representation. It is used for sets built without a schema, such
+// as those converted from other representations of Terraform values. Values
+// which are Equal have the same hash code.
+func HashValue(v interface{}) int {
+	var b strings.Builder
+	writeValue(&b, v)
+	return schema.HashString(b.String())
+}
+
+func writeValue(b *strings.Builder, v interface{}) {
+	switch v := v.(type) {
+	case MapData:
+		writeValue(b, map[string]interface{}(v))
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b.WriteString("{")
+		for _, k := range keys {
+			fmt.Fprintf(b, "%q=", k)
+			writeValue(b, v[k])
+			b.WriteString(";")
+		}
+		b.WriteString("}")
+	case []interface{}:
+		b.WriteString("[")
+		for _, item := range v {
+			writeValue(b, item)
+			b.WriteString(";")
+		}
+		b.WriteString("]")
+	case *schema.Set:
+		items := make([]string, v.Len())
+		for i, item := range v.List() {
+			var ib strings.Builder
+			writeValue(&ib, item)
+			items[i] = ib.String()
+		}
+		sort.Strings(items)
+		fmt.Fprintf(b, "<%s>", strings.Join(items, ";"))
+	default:
+		fmt.Fprintf(b, "%#v", v)
+	}
+}
@@ -0,0 +1,28 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/internal/testing/expect"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestHashValue(t *testing.T) {
+	a := map[string]interface{}{
+		"name": "foo",
+		"size": 1,
+		"tags": schema.NewSet(schema.HashString, []interface{}{"x", "y"}),
+	}
+	b := MapData{
+		"size": 1,
+		"tags": schema.NewSet(schema.HashString, []interface{}{"y", "x"}),
+		"name": "foo",
+	}
+	expect.Expect(t, HashValue(a), HashValue(b))
+	expect.Expect(t, HashValue(a) == HashValue(map[string]interface{}{"name": "foo"}), false)
+	expect.Expect(t, HashValue("1") == HashValue(1), false)
+	expect.Expect(t, HashValue([]interface{}{"a", "b"}) == HashValue([]interface{}{"b", "a"}), false)
+
+	s := schema.NewSet(HashValue, []interface{}{a, b})
+	expect.Expect(t, s.Len(), 1)
+}
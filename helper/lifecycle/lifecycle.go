@@ -0,0 +1,200 @@
+// Package lifecycle simulates the lifecycle of a resource in-process, without a
+// Terraform binary or network access, by driving a schema.Resource against a
+// fake client passed as its meta.
+//
+// A resource is created from a configuration, read back and planned a second
+// time. A non-empty second plan means the resource has a perpetual diff, which
+// is usually caused by a Read function flattening a value differently than it
+// was configured. The resource is then imported and read, and the resulting
+// state is compared to the state after creation, approximating the
+// ImportStateVerify step of an acceptance test.
+//
+//	func TestResourceServer(t *testing.T) {
+//		lifecycle.Test(t, resourceServer(), map[string]interface{}{
+//			"name": "foo",
+//		}, newFakeClient())
+//	}
+package lifecycle
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/internal/value"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// A Diff describes an attribute whose value differs between two states.
+type Diff struct {
+	Key         string
+	Old, New    string
+	Computed    bool // the new value is unknown
+	Removed     bool // the attribute is removed
+	RequiresNew bool // the change forces a new resource
+}
+
+func (d Diff) String() string {
+	n := fmt.Sprintf("%q", d.New)
+	switch {
+	case d.Computed:
+		n = "<computed>"
+	case d.Removed:
+		n = "<removed>"
+	}
+	s := fmt.Sprintf("%s: %q => %s", d.Key, d.Old, n)
+	if d.RequiresNew {
+		s += " (forces new resource)"
+	}
+	return s
+}
+
+// A Report describes the outcome of Run.
+type Report struct {
+	// State is the state of the resource after it was created and read.
+	State *terraform.InstanceState
+
+	// Plan holds the attributes changed by the plan following the creation of
+	// the resource, which must be empty.
+	Plan []Diff
+
+	// Import holds the attributes whose values differ between State and the
+	// state of the imported resource. It is empty if the resource can't be
+	// imported.
+	Import []Diff
+}
+
+// Err returns an error describing the perpetual diffs and import mismatches of
+// the report, or nil if there are none.
+func (r *Report) Err() error {
+	var b strings.Builder
+	if len(r.Plan) > 0 {
+		b.WriteString("lifecycle: plan is not empty after create and read:\n")
+		for _, d := range r.Plan {
+			fmt.Fprintf(&b, "  %s\n", d)
+		}
+	}
+	if len(r.Import) > 0 {
+		b.WriteString("lifecycle: imported state differs from created state:\n")
+		for _, d := range r.Import {
+			fmt.Fprintf(&b, "  %s\n", d)
+		}
+	}
+	if b.Len() == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.TrimSuffix(b.String(), "\n"))
+}
+
+// Run creates a resource r from config, reads it and plans config against the
+// resulting state. It then imports the resource by its ID, reads it and compares
+// the imported state with the created one, unless r has no Importer. Attributes
+// matching any of the ignore prefixes are excluded from the comparison, which
+// is useful for attributes the API doesn't return, such as passwords.
+//
+// The meta is passed to the functions of r, which is usually an in-memory fake
+// of the client used by the provider.
+//
+// An error is returned if any function of r fails. Differences are reported
+// rather than returned, see Report.Err.
+func Run(r *schema.Resource, config map[string]interface{}, meta interface{}, ignore ...string) (*Report, error) {
+	c := terraform.NewResourceConfigRaw(config)
+
+	diff, err := plan(r, nil, c, meta)
+	if err != nil {
+		return nil, fmt.Errorf("lifecycle: plan: %s", err)
+	}
+	state, err := apply(r, diff, meta)
+	if err != nil {
+		return nil, fmt.Errorf("lifecycle: create: %s", err)
+	}
+	if state == nil || state.ID == "" {
+		return nil, fmt.Errorf("lifecycle: create: resource has no ID")
+	}
+	if state, err = refresh(r, state, meta); err != nil {
+		return nil, fmt.Errorf("lifecycle: read: %s", err)
+	}
+
+	report := &Report{State: state}
+
+	diff, err = plan(r, state, c, meta)
+	if err != nil {
+		return nil, fmt.Errorf("lifecycle: second plan: %s", err)
+	}
+	if !diff.Empty() {
+		report.Plan = planDiff(diff)
+	}
+
+	if r.Importer == nil {
+		return report, nil
+	}
+	imported, err := importState(r, state.ID, meta)
+	if err != nil {
+		return nil, fmt.Errorf("lifecycle: import: %s", err)
+	}
+	report.Import = stateDiff(state.Attributes, imported.Attributes, ignore)
+
+	return report, nil
+}
+
+// Test calls Run and fails t if it returns an error or if the report isn't
+// empty.
+func Test(t testing.TB, r *schema.Resource, config map[string]interface{}, meta interface{}, ignore ...string) *Report {
+	t.Helper()
+	report, err := Run(r, config, meta, ignore...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := report.Err(); err != nil {
+		t.Error(err)
+	}
+	return report
+}
+
+func planDiff(diff *terraform.InstanceDiff) []Diff {
+	attrs := diff.CopyAttributes()
+	out := make([]Diff, 0, len(attrs))
+	for _, k := range value.SortedKeys(attrs) {
+		a := attrs[k]
+		out = append(out, Diff{
+			Key:         k,
+			Old:         a.Old,
+			New:         a.New,
+			Computed:    a.NewComputed,
+			Removed:     a.NewRemoved,
+			RequiresNew: a.RequiresNew,
+		})
+	}
+	return out
+}
+
+func stateDiff(prior, next map[string]string, ignore []string) (out []Diff) {
+	keys := make(map[string]bool)
+	for k := range prior {
+		keys[k] = true
+	}
+	for k := range next {
+		keys[k] = true
+	}
+	for _, k := range value.SortedKeys(keys) {
+		if ignored(k, ignore) {
+			continue
+		}
+		o, ok := prior[k]
+		n, nok := next[k]
+		if o != n || ok != nok {
+			out = append(out, Diff{Key: k, Old: o, New: n, Removed: !nok})
+		}
+	}
+	return
+}
+
+func ignored(key string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(key, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,119 @@
+package lifecycle
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/internal/testing/expect"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+type server struct {
+	Name     string
+	Size     int
+	Password string
+}
+
+// client is an in-memory fake of an API client.
+type client struct {
+	servers map[string]*server
+	next    int
+}
+
+func newClient() *client {
+	return &client{servers: make(map[string]*server)}
+}
+
+func (c *client) create(s *server) string {
+	c.next++
+	id := fmt.Sprintf("srv-%d", c.next)
+	c.servers[id] = s
+	return id
+}
+
+// resource returns a resource managing servers. Its Read function uses
+// normalize to flatten the name of a server.
+func resource(normalize func(string) string) *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":     {Type: schema.TypeString, Required: true},
+			"size":     {Type: schema.TypeInt, Optional: true, Default: 1},
+			"password": {Type: schema.TypeString, Optional: true, Sensitive: true},
+		},
+		Create: func(d *schema.ResourceData, meta interface{}) error {
+			d.SetId(meta.(*client).create(&server{
+				Name:     d.Get("name").(string),
+				Size:     d.Get("size").(int),
+				Password: d.Get("password").(string),
+			}))
+			return nil
+		},
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			s, ok := meta.(*client).servers[d.Id()]
+			if !ok {
+				d.SetId("")
+				return nil
+			}
+			d.Set("name", normalize(s.Name))
+			d.Set("size", s.Size)
+			return nil // the API doesn't return passwords
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			delete(meta.(*client).servers, d.Id())
+			return nil
+		},
+		Importer: &schema.ResourceImporter{},
+	}
+}
+
+func identity(s string) string { return s }
+
+func TestRun(t *testing.T) {
+	report, err := Run(resource(identity), map[string]interface{}{
+		"name": "foo",
+		"size": 2,
+	}, newClient())
+	expect.Expect(t, err, nil)
+	expect.Expect(t, report.Err(), nil)
+	expect.Expect(t, report.State.ID, "srv-1")
+	expect.Expect(t, report.State.Attributes["size"], "2")
+}
+
+func TestRunPerpetualDiff(t *testing.T) {
+	report, err := Run(resource(strings.ToLower), map[string]interface{}{
+		"name": "Foo",
+	}, newClient())
+	expect.Expect(t, err, nil)
+	expect.Expect(t, len(report.Plan), 1)
+	expect.Expect(t, report.Plan[0].Key, "name")
+	expect.Expect(t, report.Plan[0].Old, "foo")
+	expect.Expect(t, report.Plan[0].New, "Foo")
+	expect.Expect(t, report.Err().Error(), `lifecycle: plan is not empty after create and read:
+  name: "foo" => "Foo"`)
+}
+
+func TestRunImport(t *testing.T) {
+	config := map[string]interface{}{
+		"name":     "foo",
+		"password": "secret",
+	}
+	report, err := Run(resource(identity), config, newClient())
+	expect.Expect(t, err, nil)
+	expect.Expect(t, len(report.Import), 1)
+	expect.Expect(t, report.Import[0].Key, "password")
+	expect.Expect(t, report.Import[0].Removed, true)
+
+	report, err = Run(resource(identity), config, newClient(), "password")
+	expect.Expect(t, err, nil)
+	expect.Expect(t, report.Err(), nil)
+}
+
+func TestRunError(t *testing.T) {
+	r := resource(identity)
+	r.Create = func(d *schema.ResourceData, meta interface{}) error {
+		return fmt.Errorf("quota exceeded")
+	}
+	_, err := Run(r, map[string]interface{}{"name": "foo"}, newClient())
+	expect.Expect(t, err.Error(), "lifecycle: create: quota exceeded")
+}
@@ -0,0 +1,53 @@
+package lifecycle
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// The functions below call the functions of a resource, whose signatures differ
+// between versions of the SDK.
+
+func plan(r *schema.Resource, s *terraform.InstanceState, c *terraform.ResourceConfig, meta interface{}) (*terraform.InstanceDiff, error) {
+	return r.Diff(s, c, meta)
+}
+
+func apply(r *schema.Resource, d *terraform.InstanceDiff, meta interface{}) (*terraform.InstanceState, error) {
+	return r.Apply(nil, d, meta)
+}
+
+func refresh(r *schema.Resource, s *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	id := s.ID
+	s, err := r.Refresh(s, meta)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, fmt.Errorf("resource %q no longer exists", id)
+	}
+	return s, nil
+}
+
+func importState(r *schema.Resource, id string, meta interface{}) (*terraform.InstanceState, error) {
+	d := r.Data(nil)
+	d.SetId(id)
+
+	fn := r.Importer.State
+	if fn == nil {
+		fn = schema.ImportStatePassthrough
+	}
+	data, err := fn(d, meta)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != 1 {
+		return nil, fmt.Errorf("expected a single resource, got %d", len(data))
+	}
+	s := data[0].State()
+	if s == nil {
+		return nil, fmt.Errorf("imported resource has no ID")
+	}
+	return refresh(r, s, meta)
+}
@@ -0,0 +1,183 @@
+package helper
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// A Step is a single step of a Path. It is one of Key, Index, SetHash or
+// MapKey.
+type Step interface {
+	// String returns the step as it appears in a dotted path.
+	String() string
+
+	step()
+}
+
+// Key is a step selecting an attribute by name.
+type Key string
+
+// Index is a step selecting an element of a list by its index.
+type Index int
+
+// SetHash is a step selecting an element of a set by its hash code.
+type SetHash int
+
+// MapKey is a step selecting an element of a map by its key.
+type MapKey string
+
+func (k Key) String() string     { return string(k) }
+func (i Index) String() string   { return strconv.Itoa(int(i)) }
+func (h SetHash) String() string { return strconv.Itoa(int(h)) }
+func (k MapKey) String() string  { return string(k) }
+
+func (Key) step()     {}
+func (Index) step()   {}
+func (SetHash) step() {}
+func (MapKey) step()  {}
+
+// A Path identifies an attribute within a resource, such as the value at
+//
+//	task_spec.0.container_spec.0.mounts.1606541327.target
+//
+// which is represented by the steps
+//
+//	Path{Key("task_spec"), Index(0), Key("container_spec"), Index(0),
+//		Key("mounts"), SetHash(1606541327), Key("target")}
+type Path []Step
+
+// Key returns a copy of p extended with a Key step.
+func (p Path) Key(k string) Path { return p.Append(Key(k)) }
+
+// Index returns a copy of p extended with an Index step.
+func (p Path) Index(i int) Path { return p.Append(Index(i)) }
+
+// SetHash returns a copy of p extended with a SetHash step.
+func (p Path) SetHash(h int) Path { return p.Append(SetHash(h)) }
+
+// MapKey returns a copy of p extended with a MapKey step.
+func (p Path) MapKey(k string) Path { return p.Append(MapKey(k)) }
+
+// Append returns a copy of p extended with steps.
+func (p Path) Append(steps ...Step) Path {
+	out := make(Path, 0, len(p)+len(steps))
+	return append(append(out, p...), steps...)
+}
+
+// String returns the path in the dotted format used by schema.ResourceData.
+func (p Path) String() string {
+	s := make([]string, len(p))
+	for i, step := range p {
+		s[i] = step.String()
+	}
+	return strings.Join(s, ".")
+}
+
+// Cty converts p to a cty.Path. As cty identifies set elements by their value
+// rather than their hash, the path is truncated at the first SetHash step, in
+// which case ok is false.
+func (p Path) Cty() (path cty.Path, ok bool) {
+	out := make(cty.Path, 0, len(p))
+	for _, step := range p {
+		switch step := step.(type) {
+		case Key:
+			out = append(out, cty.GetAttrStep{Name: string(step)})
+		case Index:
+			out = append(out, cty.IndexStep{Key: cty.NumberIntVal(int64(step))})
+		case MapKey:
+			out = append(out, cty.IndexStep{Key: cty.StringVal(string(step))})
+		case SetHash:
+			return out, false
+		}
+	}
+	return out, true
+}
+
+// ParsePath parses a path in the dotted format used by schema.ResourceData.
+//
+// If m is non-nil, it is used to tell list indexes, set hashes and map keys
+// apart. Otherwise numeric steps are parsed as an Index and all other steps as
+// a Key.
+func ParsePath(s string, m map[string]*schema.Schema) Path {
+	if s == "" {
+		return nil
+	}
+	var (
+		p    Path
+		elem *schema.Schema
+	)
+	for _, part := range strings.Split(s, ".") {
+		switch {
+		case elem != nil:
+			p = append(p, elemStep(elem, part))
+			m, elem = elemSchema(elem)
+		case m != nil:
+			p = append(p, Key(part))
+			m, elem = nil, m[part]
+		default:
+			if i, err := strconv.Atoi(part); err == nil {
+				p = append(p, Index(i))
+			} else {
+				p = append(p, Key(part))
+			}
+		}
+	}
+	return p
+}
+
+// elemStep returns the step selecting the element part of a value described by
+// s.
+func elemStep(s *schema.Schema, part string) Step {
+	if part == "#" || part == "%" {
+		return Key(part)
+	}
+	switch s.Type {
+	case schema.TypeList:
+		if i, err := strconv.Atoi(part); err == nil {
+			return Index(i)
+		}
+	case schema.TypeSet:
+		if h, err := strconv.Atoi(part); err == nil {
+			return SetHash(h)
+		}
+	case schema.TypeMap:
+		return MapKey(part)
+	}
+	return Key(part)
+}
+
+// elemSchema returns the schema of the elements of a value described by s.
+// Either the schema map of a nested resource, or the schema of a primitive
+// element is returned.
+func elemSchema(s *schema.Schema) (map[string]*schema.Schema, *schema.Schema) {
+	switch elem := s.Elem.(type) {
+	case *schema.Resource:
+		return elem.Schema, nil
+	case *schema.Schema:
+		if elem.Type == schema.TypeList || elem.Type == schema.TypeSet || elem.Type == schema.TypeMap {
+			return nil, elem
+		}
+	}
+	return nil, nil
+}
+
+// A Pather is a ResourceData which is nested within another, such as the data
+// passed to the callback of expand.Iterator.Elem.
+type Pather interface {
+	// Path returns the path of the data within the resource.
+	Path() Path
+}
+
+// PathOf returns the path of d within the resource if it is nested, or an empty
+// path otherwise. Wrappers are looked through.
+func PathOf(d ResourceData) Path {
+	for ; d != nil; d = Unwrap(d) {
+		if p, ok := d.(Pather); ok {
+			return p.Path()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/internal/testing/expect"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var pathSchema = map[string]*schema.Schema{
+	"spec": {
+		Type: schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"mounts": {
+					Type: schema.TypeSet,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"target": {Type: schema.TypeString},
+							"labels": {
+								Type: schema.TypeMap,
+								Elem: &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestPath(t *testing.T) {
+	p := Path{}.Key("spec").Index(0).Key("mounts").SetHash(1606541327).Key("target")
+	expect.Expect(t, p.String(), "spec.0.mounts.1606541327.target")
+
+	q := p[:2].Key("other")
+	expect.Expect(t, p.String(), "spec.0.mounts.1606541327.target")
+	expect.Expect(t, q.String(), "spec.0.other")
+}
+
+func TestParsePath(t *testing.T) {
+	expect.Expect(t, ParsePath("spec.0.mounts.1606541327.labels.foo", pathSchema), Path{
+		Key("spec"), Index(0), Key("mounts"), SetHash(1606541327), Key("labels"), MapKey("foo"),
+	})
+	expect.Expect(t, ParsePath("spec.#", pathSchema), Path{Key("spec"), Key("#")})
+	expect.Expect(t, ParsePath("spec.0.mounts.1606541327", nil), Path{
+		Key("spec"), Index(0), Key("mounts"), Index(1606541327),
+	})
+	expect.Expect(t, len(ParsePath("", nil)), 0)
+}
+
+func TestPathCty(t *testing.T) {
+	p, ok := ParsePath("spec.0.mounts", pathSchema).Cty()
+	expect.Expect(t, p, cty.GetAttrPath("spec").Index(cty.NumberIntVal(0)).GetAttr("mounts"))
+	expect.Expect(t, ok, true)
+
+	p, ok = ParsePath("spec.0.mounts.1606541327.target", pathSchema).Cty()
+	expect.Expect(t, len(p), 3)
+	expect.Expect(t, ok, false)
+}
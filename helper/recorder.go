@@ -0,0 +1,298 @@
+package helper
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// A Call is a single call to a method of a ResourceData, as recorded by a
+// Recorder.
+type Call struct {
+	Method string      // name of the method called
+	Path   Path        // full path of the key
+	Value  interface{} // value returned by Get methods or passed to Set
+	File   string      // file of the call site
+	Line   int         // line of the call site
+}
+
+func (c Call) String() string {
+	return fmt.Sprintf("%s:%d: %s(%q) %#v", filepath.Base(c.File), c.Line, c.Method, c.Path.String(), c.Value)
+}
+
+// A Recorder wraps a ResourceData and records every call to its HasChange,
+// GetChange, Get, GetOk, GetOkExists and Set methods.
+//
+// It's useful to verify that an expander reads every configurable attribute
+// and that a flattener sets every attribute of a schema.
+//
+//	r := helper.NewRecorder(d)
+//	api := expandServer(r)
+//
+//	r.ExpectCoverage(t, resourceServer().Schema)
+type Recorder struct {
+	ResourceData
+	calls []Call
+}
+
+// NewRecorder returns a Recorder wrapping d.
+func NewRecorder(d ResourceData) *Recorder {
+	return &Recorder{ResourceData: d}
+}
+
+// Calls returns the calls recorded so far.
+func (r *Recorder) Calls() []Call {
+	return r.calls
+}
+
+// Unwrap returns the wrapped data.
+func (r *Recorder) Unwrap() ResourceData {
+	return r.ResourceData
+}
+
+// Path returns the path of the wrapped data.
+func (r *Recorder) Path() Path {
+	return PathOf(r.ResourceData)
+}
+
+// HasChange records the call and calls HasChange of the wrapped data.
+func (r *Recorder) HasChange(key string) bool {
+	ok := r.ResourceData.HasChange(key)
+	r.record("HasChange", key, ok)
+	return ok
+}
+
+// GetChange records the call and calls GetChange of the wrapped data. The new
+// value is recorded.
+func (r *Recorder) GetChange(key string) (interface{}, interface{}) {
+	o, n := r.ResourceData.GetChange(key)
+	r.record("GetChange", key, n)
+	return o, n
+}
+
+// Get records the call and calls Get of the wrapped data.
+func (r *Recorder) Get(key string) interface{} {
+	v := r.ResourceData.Get(key)
+	r.record("Get", key, v)
+	return v
+}
+
+// GetOk records the call and calls GetOk of the wrapped data.
+func (r *Recorder) GetOk(key string) (interface{}, bool) {
+	v, ok := r.ResourceData.GetOk(key)
+	r.record("GetOk", key, v)
+	return v, ok
+}
+
+// GetOkExists records the call and calls GetOkExists of the wrapped data.
+func (r *Recorder) GetOkExists(key string) (interface{}, bool) {
+	v, ok := r.ResourceData.GetOkExists(key)
+	r.record("GetOkExists", key, v)
+	return v, ok
+}
+
+// Set records the call and calls Set of the wrapped data.
+func (r *Recorder) Set(key string, v interface{}) error {
+	r.record("Set", key, v)
+	return r.ResourceData.Set(key, v)
+}
+
+func (r *Recorder) record(method, key string, v interface{}) {
+	file, line := caller()
+	r.calls = append(r.calls, Call{
+		Method: method,
+		Path:   r.Path().Append(ParsePath(key, nil)...),
+		Value:  v,
+		File:   file,
+		Line:   line,
+	})
+}
+
+// dir is the directory of the helper package. Calls made from within it, or
+// from the packages nested in it, aren't reported as call sites.
+var dir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file) + string(filepath.Separator)
+}()
+
+func caller() (string, int) {
+	pc := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pc[:runtime.Callers(3, pc)])
+	for {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.File, dir) || strings.HasSuffix(f.File, "_test.go") {
+			return f.File, f.Line
+		}
+		if !more {
+			return f.File, f.Line
+		}
+	}
+}
+
+// Read reports whether the attribute name was read. Nested attributes are named
+// by the keys of their enclosing blocks, such as "task_spec.container_spec".
+func (r *Recorder) Read(name string) bool {
+	return r.names(isRead)[name]
+}
+
+// Written reports whether the attribute name was set, either directly or as
+// part of the value of its enclosing block.
+func (r *Recorder) Written(name string) bool {
+	return r.names(isSet)[name]
+}
+
+// TestingT is the subset of testing.TB used by the Expect methods of a
+// Recorder, so that providers don't link the testing package into their binary.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// ExpectRead fails t if any of the attributes names wasn't read.
+func (r *Recorder) ExpectRead(t TestingT, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		if !r.Read(name) {
+			t.Errorf("expected %q to be read", name)
+		}
+	}
+}
+
+// ExpectWritten fails t if any of the attributes names wasn't set.
+func (r *Recorder) ExpectWritten(t TestingT, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		if !r.Written(name) {
+			t.Errorf("expected %q to be set", name)
+		}
+	}
+}
+
+// A Coverage describes which attributes of a schema were accessed through a
+// Recorder.
+type Coverage struct {
+	NotRead []string // configurable attributes which were never read
+	NotSet  []string // attributes which were never set
+	Unknown []string // attributes which were accessed but aren't in the schema
+}
+
+// Coverage returns the coverage of the attributes described by m.
+func (r *Recorder) Coverage(m map[string]*schema.Schema) Coverage {
+	var c Coverage
+	read, set := r.names(isRead), r.names(isSet)
+	walkSchema(m, "", func(name string, s *schema.Schema) {
+		if Configurable(s) && !read[name] {
+			c.NotRead = append(c.NotRead, name)
+		}
+		if !set[name] {
+			c.NotSet = append(c.NotSet, name)
+		}
+	})
+	for name := range r.names(func(string) bool { return true }) {
+		if !inSchema(m, strings.Split(name, ".")) {
+			c.Unknown = append(c.Unknown, name)
+		}
+	}
+	sort.Strings(c.NotRead)
+	sort.Strings(c.NotSet)
+	sort.Strings(c.Unknown)
+	return c
+}
+
+// ExpectCoverage fails t if any configurable attribute described by m was never
+// read, or if any attribute not described by m was accessed. Use Coverage to
+// check which attributes were set.
+func (r *Recorder) ExpectCoverage(t TestingT, m map[string]*schema.Schema) {
+	t.Helper()
+	c := r.Coverage(m)
+	if len(c.NotRead) > 0 {
+		t.Errorf("expected attributes %s to be read", strings.Join(c.NotRead, ", "))
+	}
+	if len(c.Unknown) > 0 {
+		t.Errorf("unexpected access to attributes %s which are not in the schema", strings.Join(c.Unknown, ", "))
+	}
+}
+
+func isRead(method string) bool { return method != "Set" && method != "HasChange" }
+func isSet(method string) bool  { return method == "Set" }
+
+// names returns the names of the attributes accessed by calls whose method
+// matches fn. Values set are walked, so that nested attributes are included.
+func (r *Recorder) names(fn func(string) bool) map[string]bool {
+	names := make(map[string]bool)
+	for _, c := range r.calls {
+		if !fn(c.Method) {
+			continue
+		}
+		name := attrName(c.Path)
+		if name == "" {
+			continue
+		}
+		names[name] = true
+		if c.Method == "Set" {
+			walkValue(name, c.Value, names)
+		}
+	}
+	return names
+}
+
+// attrName returns the name of the attribute at p, omitting the steps selecting
+// elements.
+func attrName(p Path) string {
+	var parts []string
+	for _, step := range p {
+		if k, ok := step.(Key); ok && k != "#" && k != "%" {
+			parts = append(parts, string(k))
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+func walkValue(name string, v interface{}, names map[string]bool) {
+	switch v := v.(type) {
+	case MapData:
+		walkValue(name, map[string]interface{}(v), names)
+	case map[string]interface{}:
+		for k, item := range v {
+			names[name+"."+k] = true
+			walkValue(name+"."+k, item, names)
+		}
+	case []interface{}:
+		for _, item := range v {
+			walkValue(name, item, names)
+		}
+	case []map[string]interface{}:
+		for _, item := range v {
+			walkValue(name, item, names)
+		}
+	case *schema.Set:
+		walkValue(name, v.List(), names)
+	}
+}
+
+func walkSchema(m map[string]*schema.Schema, prefix string, fn func(string, *schema.Schema)) {
+	for k, s := range m {
+		fn(prefix+k, s)
+		if r, ok := s.Elem.(*schema.Resource); ok {
+			walkSchema(r.Schema, prefix+k+".", fn)
+		}
+	}
+}
+
+// inSchema reports whether the attribute named by parts is described by m.
+// Keys of maps, and of any value which isn't a nested block, are considered
+// part of their attribute.
+func inSchema(m map[string]*schema.Schema, parts []string) bool {
+	s, ok := m[parts[0]]
+	if !ok {
+		return false
+	}
+	if r, ok := s.Elem.(*schema.Resource); ok && len(parts) > 1 {
+		return inSchema(r.Schema, parts[1:])
+	}
+	return true
+}
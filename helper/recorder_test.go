@@ -0,0 +1,85 @@
+package helper
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+var recorderSchema = map[string]*schema.Schema{
+	"name": {Type: schema.TypeString, Required: true},
+	"arn":  {Type: schema.TypeString, Computed: true},
+	"spec": {
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"image":  {Type: schema.TypeString, Optional: true},
+				"labels": {Type: schema.TypeMap, Optional: true},
+			},
+		},
+	},
+}
+
+func TestRecorder(t *testing.T) {
+	r := NewRecorder(MapData{
+		"name": "foo",
+		"spec": []interface{}{map[string]interface{}{"image": "nginx"}},
+	})
+
+	r.Get("name")
+	r.GetOk("spec.0.image")
+	r.HasChange("spec")
+
+	calls := r.Calls()
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 calls, got %d", len(calls))
+	}
+	c := calls[1]
+	if c.Method != "GetOk" || !reflect.DeepEqual(c.Path, Path{Key("spec"), Index(0), Key("image")}) || c.Value != "nginx" {
+		t.Errorf("unexpected call %s", c)
+	}
+	if filepath.Base(c.File) != "recorder_test.go" {
+		t.Errorf("expected call site in recorder_test.go, got %s", c.File)
+	}
+
+	if !r.Read("spec.image") {
+		t.Error("expected spec.image to be read")
+	}
+	if r.Read("spec") {
+		t.Error("expected HasChange not to count as a read")
+	}
+}
+
+func TestRecorderCoverage(t *testing.T) {
+	r := NewRecorder(make(MapData))
+
+	r.Get("name")
+	r.Get("size")
+	r.Set("arn", "arn:foo")
+	r.Set("spec", []interface{}{
+		map[string]interface{}{
+			"image":  "nginx",
+			"labels": map[string]interface{}{"app": "web"},
+		},
+	})
+
+	c := r.Coverage(recorderSchema)
+	for _, test := range []struct {
+		name           string
+		expect, actual []string
+	}{
+		{"NotRead", []string{"spec", "spec.image", "spec.labels"}, c.NotRead},
+		{"NotSet", []string{"name"}, c.NotSet},
+		{"Unknown", []string{"size"}, c.Unknown},
+	} {
+		if !reflect.DeepEqual(test.expect, test.actual) {
+			t.Errorf("expected %s to be %v, got %v", test.name, test.expect, test.actual)
+		}
+	}
+	if !r.Written("spec.labels") {
+		t.Error("expected spec.labels to be set as part of spec")
+	}
+}
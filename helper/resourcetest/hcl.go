@@ -0,0 +1,44 @@
+package resourcetest
+
+import (
+	"github.com/alexkappa/terraform-plugin-helper/internal/hclconfig"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// NewCreateHCL is like NewCreate, except that the configuration is given as
+// the body of resource r, written in the same syntax as a user's
+// configuration. Nested resources are written as blocks.
+//
+//	d, err := resourcetest.NewCreateHCL(r, `
+//		name = "foo"
+//		task_spec {
+//			container_spec {
+//				mounts {
+//					target = "/mnt"
+//				}
+//			}
+//		}
+//	`)
+func NewCreateHCL(r *schema.Resource, config string) (*schema.ResourceData, error) {
+	c, err := hclconfig.Decode("config.tf", config, r.Schema)
+	if err != nil {
+		return nil, err
+	}
+	return NewCreate(r.Schema, c)
+}
+
+// NewUpdateHCL is like NewUpdate, except that the prior state and the
+// configuration are given as the body of resource r, the same way as they are
+// with NewCreateHCL. The prior state may hold computed attributes and, unless
+// r has an attribute of the same name, the "id" of the resource.
+func NewUpdateHCL(r *schema.Resource, priorState, config string) (*schema.ResourceData, error) {
+	state, err := hclconfig.Decode("state.tf", priorState, r.Schema)
+	if err != nil {
+		return nil, err
+	}
+	c, err := hclconfig.Decode("config.tf", config, r.Schema)
+	if err != nil {
+		return nil, err
+	}
+	return NewUpdate(r.Schema, state, c)
+}
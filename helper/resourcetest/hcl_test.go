@@ -0,0 +1,79 @@
+package resourcetest
+
+import (
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/internal/testing/expect"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+var r = &schema.Resource{Schema: map[string]*schema.Schema{
+	"name": {Type: schema.TypeString, Optional: true},
+	"size": {Type: schema.TypeInt, Optional: true},
+	"arn":  {Type: schema.TypeString, Computed: true},
+	"tags": {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+	"mount": {Type: schema.TypeList, Optional: true, Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"target":    {Type: schema.TypeString, Required: true},
+			"read_only": {Type: schema.TypeBool, Optional: true},
+		},
+	}},
+}}
+
+func TestNewCreateHCL(t *testing.T) {
+	d, err := NewCreateHCL(r, `
+		name = "foo"
+		tags = ["a", "b"]
+
+		mount {
+			target = "/mnt/a"
+		}
+		mount {
+			target    = "/mnt/b"
+			read_only = true
+		}
+	`)
+	expect.Expect(t, err, nil)
+	expect.Expect(t, d.IsNewResource(), true)
+	expect.Expect(t, d.Get("name"), "foo")
+	expect.Expect(t, d.Get("tags").(*schema.Set).Len(), 2)
+	expect.Expect(t, d.Get("mount.#"), 2)
+	expect.Expect(t, d.Get("mount.1.target"), "/mnt/b")
+	expect.Expect(t, d.Get("mount.1.read_only"), true)
+}
+
+func TestNewUpdateHCL(t *testing.T) {
+	d, err := NewUpdateHCL(r, `
+		id   = "i-123"
+		name = "foo"
+		size = 1
+		arn  = "arn:foo"
+		mount {
+			target = "/mnt"
+		}
+	`, `
+		name = "foo"
+		size = 2
+	`)
+	expect.Expect(t, err, nil)
+	expect.Expect(t, d.IsNewResource(), false)
+	expect.Expect(t, d.Id(), "i-123")
+	expect.Expect(t, d.HasChange("name"), false)
+	expect.Expect(t, d.HasChange("size"), true)
+	expect.Expect(t, d.HasChange("mount"), true)
+	expect.Expect(t, d.Get("arn"), "arn:foo")
+	expect.Expect(t, d.Get("mount.#"), 0)
+}
+
+func TestNewCreateHCLError(t *testing.T) {
+	for _, config := range []string{
+		`name = `,
+		`name = ["foo"]`,
+		`unknown = "foo"`,
+		`mount { unknown = true }`,
+	} {
+		if _, err := NewCreateHCL(r, config); err == nil {
+			t.Errorf("Expected an error decoding %q", config)
+		}
+	}
+}
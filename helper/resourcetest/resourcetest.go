@@ -0,0 +1,108 @@
+// Package resourcetest builds schema.ResourceData the same way the SDK does
+// during each operation of a resource, so expanders and flatteners can be
+// tested without a Terraform binary.
+//
+// Configurations and states are given in their raw form, the same one accepted
+// by schema.TestResourceDataRaw.
+//
+//	d, err := resourcetest.NewUpdate(s, map[string]interface{}{
+//		"name": "foo",
+//	}, map[string]interface{}{
+//		"name": "bar",
+//	})
+//
+//	d.HasChange("name") // true
+package resourcetest
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// DefaultID is the ID of resources built from a state which has no "id".
+const DefaultID = "id"
+
+// NewCreate returns the data passed to the Create function of a resource with
+// schema s, configured with config. The resource is new and all configured
+// attributes have changed.
+func NewCreate(s map[string]*schema.Schema, config map[string]interface{}) (*schema.ResourceData, error) {
+	d, err := data(s, nil, config)
+	if err != nil {
+		return nil, err
+	}
+	d.MarkNewResource()
+	return d, nil
+}
+
+// NewUpdate returns the data passed to the Update function of a resource with
+// schema s, whose prior state is priorState and whose configuration is config.
+// Attributes differing between the two have changed.
+func NewUpdate(s map[string]*schema.Schema, priorState, config map[string]interface{}) (*schema.ResourceData, error) {
+	state, err := State(s, priorState)
+	if err != nil {
+		return nil, err
+	}
+	return data(s, state, config)
+}
+
+// NewUpdateFromState is like NewUpdate, except that the prior state is given in
+// its flattened form.
+func NewUpdateFromState(s map[string]*schema.Schema, priorState *terraform.InstanceState, config map[string]interface{}) (*schema.ResourceData, error) {
+	return data(s, priorState, config)
+}
+
+// NewRead returns the data passed to the Read function of a resource with
+// schema s and state state. No attribute has changed.
+func NewRead(s map[string]*schema.Schema, state map[string]interface{}) (*schema.ResourceData, error) {
+	is, err := State(s, state)
+	if err != nil {
+		return nil, err
+	}
+	return schema.InternalMap(s).Data(is, nil)
+}
+
+// NewImport returns the data passed to the importer of a resource with schema
+// s, holding nothing but the ID being imported.
+func NewImport(s map[string]*schema.Schema, id string) (*schema.ResourceData, error) {
+	d, err := schema.InternalMap(s).Data(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(id)
+	return d, nil
+}
+
+// State returns the state of a resource with schema s holding the values of
+// raw. Unlike a configuration, the state may hold computed attributes.
+//
+// The ID of the resource is read from the "id" key of raw, unless s describes
+// an attribute named "id". It defaults to DefaultID.
+func State(s map[string]*schema.Schema, raw map[string]interface{}) (*terraform.InstanceState, error) {
+	d, err := schema.InternalMap(s).Data(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	id := DefaultID
+	for k, v := range raw {
+		if _, ok := s[k]; !ok && k == "id" {
+			id = fmt.Sprint(v)
+			continue
+		}
+		if err := d.Set(k, v); err != nil {
+			return nil, fmt.Errorf("resourcetest: unable to set %q: %s", k, err)
+		}
+	}
+	d.SetId(id)
+	return d.State(), nil
+}
+
+func data(s map[string]*schema.Schema, state *terraform.InstanceState, config map[string]interface{}) (*schema.ResourceData, error) {
+	sm := schema.InternalMap(s)
+	diff, err := plan(sm, state, terraform.NewResourceConfigRaw(config))
+	if err != nil {
+		return nil, err
+	}
+	return sm.Data(state, diff)
+}
@@ -0,0 +1,75 @@
+package resourcetest
+
+import (
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/internal/testing/expect"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+var s = map[string]*schema.Schema{
+	"name": {Type: schema.TypeString, Optional: true},
+	"size": {Type: schema.TypeInt, Optional: true},
+	"arn":  {Type: schema.TypeString, Computed: true},
+	"tags": {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+}
+
+func TestNewCreate(t *testing.T) {
+	d, err := NewCreate(s, map[string]interface{}{
+		"name": "foo",
+		"tags": []interface{}{"a", "b"},
+	})
+	expect.Expect(t, err, nil)
+	expect.Expect(t, d.IsNewResource(), true)
+	expect.Expect(t, d.HasChange("name"), true)
+	expect.Expect(t, d.Get("name"), "foo")
+	expect.Expect(t, d.Get("tags").(*schema.Set).Len(), 2)
+	expect.Expect(t, d.Id(), "")
+}
+
+func TestNewUpdate(t *testing.T) {
+	d, err := NewUpdate(s, map[string]interface{}{
+		"id":   "i-123",
+		"name": "foo",
+		"size": 1,
+		"arn":  "arn:foo",
+	}, map[string]interface{}{
+		"name": "foo",
+		"size": 2,
+	})
+	expect.Expect(t, err, nil)
+	expect.Expect(t, d.IsNewResource(), false)
+	expect.Expect(t, d.Id(), "i-123")
+	expect.Expect(t, d.HasChange("name"), false)
+	expect.Expect(t, d.HasChange("size"), true)
+	expect.Expect(t, d.Get("arn"), "arn:foo")
+
+	o, n := d.GetChange("size")
+	expect.Expect(t, o, 1)
+	expect.Expect(t, n, 2)
+}
+
+func TestNewRead(t *testing.T) {
+	d, err := NewRead(s, map[string]interface{}{
+		"name": "foo",
+		"arn":  "arn:foo",
+	})
+	expect.Expect(t, err, nil)
+	expect.Expect(t, d.Id(), DefaultID)
+	expect.Expect(t, d.HasChange("name"), false)
+	expect.Expect(t, d.Get("name"), "foo")
+	expect.Expect(t, d.Get("arn"), "arn:foo")
+}
+
+func TestNewImport(t *testing.T) {
+	d, err := NewImport(s, "i-123")
+	expect.Expect(t, err, nil)
+	expect.Expect(t, d.Id(), "i-123")
+	expect.Expect(t, d.Get("name"), "")
+}
+
+func TestStateInvalid(t *testing.T) {
+	if _, err := State(s, map[string]interface{}{"size": "large"}); err == nil {
+		t.Error("expected an error setting an invalid value")
+	}
+}
@@ -0,0 +1,12 @@
+package resourcetest
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// plan returns the diff of config against state. It is kept apart as its
+// signature differs between versions of the SDK.
+func plan(sm schema.InternalMap, state *terraform.InstanceState, config *terraform.ResourceConfig) (*terraform.InstanceDiff, error) {
+	return sm.Diff(state, config, nil, nil, true)
+}
@@ -0,0 +1,402 @@
+// Package roundtrip checks that an expand function and a flatten function agree
+// with each other, by running them against randomly generated API objects and
+// configurations.
+//
+// An API object is flattened, read back by expand and flattened again, while a
+// configuration is expanded and flattened back. In both cases the attributes
+// before and after the round trip must be equal, otherwise the path of the
+// first differing attribute is reported.
+//
+//	func TestMountRoundTrip(t *testing.T) {
+//		c := &roundtrip.Checker[*Mount]{
+//			Schema:  mountSchema,
+//			Expand:  expandMount,
+//			Flatten: flattenMount,
+//		}
+//		c.Check(t, nil)
+//	}
+package roundtrip
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/alexkappa/terraform-plugin-helper/helper/expand"
+	"github.com/alexkappa/terraform-plugin-helper/helper/resourcetest"
+	"github.com/alexkappa/terraform-plugin-helper/internal/value"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// A Checker checks the round trip of values of type T through Expand and
+// Flatten, which operate on the attributes described by Schema.
+type Checker[T any] struct {
+	Schema  map[string]*schema.Schema
+	Expand  func(helper.ResourceData) T
+	Flatten func(T, helper.ResourceData)
+}
+
+// A Mismatch describes an attribute whose value changed during a round trip.
+type Mismatch struct {
+	Direction string      // either "flatten-expand" or "expand-flatten"
+	Path      helper.Path // path of the attribute
+	Want, Got interface{} // values before and after the round trip
+}
+
+func (m *Mismatch) Error() string {
+	return fmt.Sprintf("roundtrip: %s: value of %q changed from %#v to %#v", m.Direction, m.Path.String(), m.Want, m.Got)
+}
+
+// Check runs CheckSeed with random seeds, as many times as configured by c,
+// which may be nil. The seed of a failing check is reported, so it can be
+// reproduced by calling CheckSeed.
+func (c *Checker[T]) Check(t testing.TB, config *quick.Config) {
+	t.Helper()
+	r, n := rand.New(rand.NewSource(time.Now().UnixNano())), 100
+	if config != nil {
+		if config.Rand != nil {
+			r = config.Rand
+		}
+		if config.MaxCount > 0 {
+			n = config.MaxCount
+		} else if config.MaxCountScale > 0 {
+			n = int(config.MaxCountScale * float64(n))
+		}
+	}
+	for i := 0; i < n; i++ {
+		seed := r.Int63()
+		if err := c.CheckSeed(seed); err != nil {
+			t.Fatalf("seed %d: %s", seed, err)
+		}
+	}
+}
+
+// Fuzz registers a fuzz target running CheckSeed with the seeds generated by
+// the fuzzing engine.
+//
+//	func FuzzMount(f *testing.F) {
+//		c.Fuzz(f)
+//	}
+func (c *Checker[T]) Fuzz(f *testing.F) {
+	f.Add(int64(0))
+	f.Fuzz(func(t *testing.T, seed int64) {
+		if err := c.CheckSeed(seed); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// CheckSeed generates an API object and a configuration using seed, and checks
+// their round trip using FlattenExpand and ExpandFlatten. Panics of Expand or
+// Flatten are returned as errors.
+func (c *Checker[T]) CheckSeed(seed int64) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("roundtrip: panic: %v", v)
+		}
+	}()
+	r := rand.New(rand.NewSource(seed))
+	v, err := c.Value(r)
+	if err != nil {
+		return err
+	}
+	if err := c.FlattenExpand(v); err != nil {
+		return err
+	}
+	return c.ExpandFlatten(c.Config(r))
+}
+
+// Value returns a random value of type T using quick.Value. Top level pointers
+// and pointers held by slices and maps are never nil, while pointers held by
+// struct fields may be.
+func (c *Checker[T]) Value(r *rand.Rand) (v T, err error) {
+	rv, ok := nonNil(reflect.TypeOf(&v).Elem(), r)
+	if !ok {
+		return v, fmt.Errorf("roundtrip: unable to generate a value of type %T", v)
+	}
+	return rv.Interface().(T), nil
+}
+
+func nonNil(t reflect.Type, r *rand.Rand) (reflect.Value, bool) {
+	for {
+		rv, ok := quick.Value(t, r)
+		if !ok {
+			return rv, false
+		}
+		if t.Kind() != reflect.Ptr || !rv.IsNil() {
+			return rv, fillNil(rv, r)
+		}
+	}
+}
+
+// fillNil replaces nil pointers held by slices and maps within rv.
+func fillNil(rv reflect.Value, r *rand.Rand) bool {
+	switch rv.Kind() {
+	case reflect.Ptr:
+		return rv.IsNil() || fillNil(rv.Elem(), r)
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			if rv.Type().Field(i).IsExported() && !fillNil(rv.Field(i), r) {
+				return false
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if rv.Index(i).Kind() == reflect.Ptr && rv.Index(i).IsNil() {
+				elem, ok := nonNil(rv.Index(i).Type(), r)
+				if !ok {
+					return false
+				}
+				rv.Index(i).Set(elem)
+			} else if !fillNil(rv.Index(i), r) {
+				return false
+			}
+		}
+	case reflect.Map:
+		iter := rv.MapRange()
+		for iter.Next() {
+			if iter.Value().Kind() != reflect.Ptr || !iter.Value().IsNil() {
+				continue
+			}
+			elem, ok := nonNil(rv.Type().Elem(), r)
+			if !ok {
+				return false
+			}
+			rv.SetMapIndex(iter.Key(), elem)
+		}
+	}
+	return true
+}
+
+// FlattenExpand flattens v, expands the result and flattens it again. The
+// attributes of both flattened values must be equal.
+//
+// The data passed to Expand is read in full, as if v was part of the state of
+// a resource.
+func (c *Checker[T]) FlattenExpand(v T) error {
+	before, err := c.flatten(v)
+	if err != nil {
+		return err
+	}
+	after, err := c.flatten(c.Expand(expand.WithMode(before, expand.Full)))
+	if err != nil {
+		return err
+	}
+	return c.compare("flatten-expand", before, after, func(*schema.Schema) bool { return true })
+}
+
+// ExpandFlatten expands the data of a resource created from config, and
+// flattens the result. Configurable attributes of the resource must be equal to
+// the flattened ones.
+func (c *Checker[T]) ExpandFlatten(config map[string]interface{}) error {
+	before, err := resourcetest.NewCreate(c.Schema, config)
+	if err != nil {
+		return fmt.Errorf("roundtrip: invalid configuration: %s", err)
+	}
+	after, err := c.flatten(c.Expand(before))
+	if err != nil {
+		return err
+	}
+	return c.compare("expand-flatten", before, after, helper.Configurable)
+}
+
+// flatten flattens v, returning it as the state of a resource.
+func (c *Checker[T]) flatten(v T) (*schema.ResourceData, error) {
+	d := helper.NewMapData(c.Schema)
+	c.Flatten(v, d)
+	if err := d.Err(); err != nil {
+		return nil, fmt.Errorf("roundtrip: flatten: %s", err)
+	}
+	return resourcetest.NewRead(c.Schema, d.MapData)
+}
+
+func (c *Checker[T]) compare(direction string, before, after *schema.ResourceData, include func(*schema.Schema) bool) error {
+	for _, k := range value.SortedKeys(c.Schema) {
+		s := c.Schema[k]
+		if !include(s) {
+			continue
+		}
+		if m := compare(helper.Path{helper.Key(k)}, before.Get(k), after.Get(k), s); m != nil {
+			m.Direction = direction
+			return m
+		}
+	}
+	return nil
+}
+
+// compare returns a mismatch holding the path of the first differing value
+// between a and b, described by elem, which is either a *schema.Schema or a
+// *schema.Resource.
+func compare(p helper.Path, a, b interface{}, elem interface{}) *Mismatch {
+	if r, ok := elem.(*schema.Resource); ok {
+		am, _ := a.(map[string]interface{})
+		bm, _ := b.(map[string]interface{})
+		for _, k := range value.SortedKeys(r.Schema) {
+			if m := compare(p.Key(k), am[k], bm[k], r.Schema[k]); m != nil {
+				return m
+			}
+		}
+		return nil
+	}
+	s := elem.(*schema.Schema)
+	switch a := a.(type) {
+	case []interface{}:
+		b, _ := b.([]interface{})
+		for i := 0; i < len(a) && i < len(b); i++ {
+			if m := compare(p.Index(i), a[i], b[i], helper.ElemOf(s)); m != nil {
+				return m
+			}
+		}
+		if len(a) != len(b) {
+			return &Mismatch{Path: p.Key("#"), Want: len(a), Got: len(b)}
+		}
+		return nil
+	case *schema.Set:
+		b, _ := b.(*schema.Set)
+		if b == nil {
+			b = schema.NewSet(a.F, nil)
+		}
+		ad, bd := a.Difference(b).List(), b.Difference(a).List()
+		switch {
+		case len(ad) == 1 && len(bd) == 1:
+			return compare(p.SetHash(value.HashCode(a, ad[0])), ad[0], bd[0], helper.ElemOf(s))
+		case len(ad) > 0:
+			return &Mismatch{Path: p.SetHash(value.HashCode(a, ad[0])), Want: ad[0], Got: nil}
+		case len(bd) > 0:
+			return &Mismatch{Path: p.SetHash(value.HashCode(a, bd[0])), Want: nil, Got: bd[0]}
+		}
+		return nil
+	case map[string]interface{}:
+		b, _ := b.(map[string]interface{})
+		keys := make(map[string]interface{})
+		for k := range a {
+			keys[k] = nil
+		}
+		for k := range b {
+			keys[k] = nil
+		}
+		for _, k := range value.SortedKeys(keys) {
+			if m := compare(p.MapKey(k), a[k], b[k], helper.ElemOf(s)); m != nil {
+				return m
+			}
+		}
+		return nil
+	}
+	if !helper.Equal(a, b) {
+		return &Mismatch{Path: p, Want: a, Got: b}
+	}
+	return nil
+}
+
+// Config returns a random configuration of the attributes described by the
+// schema. Required attributes are always set, while optional ones are set at
+// random. Attributes conflicting with one which is already set are omitted,
+// and values are generated again if they fail validation.
+func (c *Checker[T]) Config(r *rand.Rand) map[string]interface{} {
+	return config(r, c.Schema)
+}
+
+func config(r *rand.Rand, m map[string]*schema.Schema) map[string]interface{} {
+	out := make(map[string]interface{})
+	for _, k := range value.SortedKeys(m) {
+		s := m[k]
+		if !helper.Configurable(s) || (!s.Required && r.Intn(2) == 0) || conflicts(out, s) {
+			continue
+		}
+		if v, ok := randomValue(r, k, s); ok {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func conflicts(config map[string]interface{}, s *schema.Schema) bool {
+	for _, k := range s.ConflictsWith {
+		if _, ok := config[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+const maxAttempts = 10
+
+// randomValue returns a random value of the attribute k described by s.
+func randomValue(r *rand.Rand, k string, s *schema.Schema) (interface{}, bool) {
+	switch s.Type {
+	case schema.TypeList, schema.TypeSet:
+		max := s.MaxItems
+		if max == 0 {
+			max = 3
+		}
+		n := s.MinItems + r.Intn(max-s.MinItems+1)
+		if n == 0 {
+			n = 1
+		}
+		out := make([]interface{}, 0, n)
+		for i := 0; i < n; i++ {
+			if v, ok := elemValue(r, k, helper.ElemOf(s)); ok {
+				out = append(out, v)
+			}
+		}
+		return out, len(out) > 0
+	case schema.TypeMap:
+		out := make(map[string]interface{})
+		for i := r.Intn(3) + 1; i > 0; i-- {
+			if v, ok := elemValue(r, k, helper.ElemOf(s)); ok {
+				out[randString(r)] = v
+			}
+		}
+		return out, len(out) > 0
+	}
+	for i := 0; i < maxAttempts; i++ {
+		v := primitive(r, s.Type)
+		if s.ValidateFunc == nil {
+			return v, true
+		}
+		if _, errs := s.ValidateFunc(v, k); len(errs) == 0 {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func elemValue(r *rand.Rand, k string, elem interface{}) (interface{}, bool) {
+	switch elem := elem.(type) {
+	case *schema.Resource:
+		for i := 0; i < maxAttempts; i++ {
+			// blocks without any attributes are dropped by the SDK
+			if m := config(r, elem.Schema); len(m) > 0 {
+				return m, true
+			}
+		}
+	case *schema.Schema:
+		return randomValue(r, k, elem)
+	}
+	return nil, false
+}
+
+func primitive(r *rand.Rand, t schema.ValueType) interface{} {
+	switch t {
+	case schema.TypeBool:
+		return r.Intn(2) == 0
+	case schema.TypeInt:
+		return r.Intn(2000) - 1000
+	case schema.TypeFloat:
+		return float64(r.Intn(2000)-1000) / 8
+	}
+	return randString(r)
+}
+
+func randString(r *rand.Rand) string {
+	const chars = "abcdefghijklmnopqrstuvwxyz0123456789"
+	b := make([]byte, r.Intn(8)+1)
+	for i := range b {
+		b[i] = chars[r.Intn(len(chars))]
+	}
+	return string(b)
+}
@@ -0,0 +1,145 @@
+package roundtrip
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+	"testing"
+	"testing/quick"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/alexkappa/terraform-plugin-helper/helper/expand"
+	"github.com/alexkappa/terraform-plugin-helper/helper/flatten"
+	"github.com/alexkappa/terraform-plugin-helper/internal/testing/expect"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+type Mount struct {
+	Target string
+	Source string
+	Size   int
+}
+
+type Container struct {
+	Image  string
+	Mounts []*Mount
+	Labels map[string]string
+}
+
+var s = map[string]*schema.Schema{
+	"image": {Type: schema.TypeString, Required: true},
+	"mounts": {
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"target": {Type: schema.TypeString, Required: true},
+				"source": {Type: schema.TypeString, Optional: true},
+				"size":   {Type: schema.TypeInt, Optional: true},
+			},
+		},
+	},
+	"labels": {Type: schema.TypeMap, Optional: true},
+}
+
+func expandContainer(d helper.ResourceData) *Container {
+	labels, _ := expand.Get[map[string]string](d, "labels")
+	c := &Container{
+		Image:  expand.String(d, "image"),
+		Labels: labels,
+	}
+	expand.Set(d, "mounts").Elem(func(d helper.ResourceData) {
+		c.Mounts = append(c.Mounts, &Mount{
+			Target: expand.String(d, "target"),
+			Source: expand.String(d, "source"),
+			Size:   expand.Int(d, "size"),
+		})
+	})
+	return c
+}
+
+func flattenMount(m *Mount, d helper.ResourceData) {
+	d.Set("target", m.Target)
+	d.Set("source", m.Source)
+	d.Set("size", m.Size)
+}
+
+func flattenContainer(c *Container, d helper.ResourceData) {
+	d.Set("image", c.Image)
+	d.Set("mounts", flatten.SliceFunc(c.Mounts, flattenMount))
+	d.Set("labels", c.Labels)
+}
+
+func TestCheck(t *testing.T) {
+	c := &Checker[*Container]{s, expandContainer, flattenContainer}
+	c.Check(t, &quick.Config{Rand: rand.New(rand.NewSource(1))})
+}
+
+// firstMismatch returns the first mismatch reported by c.CheckSeed.
+func firstMismatch(t *testing.T, c *Checker[*Container]) *Mismatch {
+	for seed := int64(0); seed < 100; seed++ {
+		err := c.CheckSeed(seed)
+		if err == nil {
+			continue
+		}
+		var m *Mismatch
+		if !errors.As(err, &m) {
+			t.Fatalf("expected a *Mismatch, got %s", err)
+		}
+		return m
+	}
+	t.Fatal("expected a mismatch")
+	return nil
+}
+
+func TestCheckExpandMismatch(t *testing.T) {
+	c := &Checker[*Container]{s, func(d helper.ResourceData) *Container {
+		c := expandContainer(d)
+		c.Labels = nil // labels are dropped
+		return c
+	}, flattenContainer}
+
+	m := firstMismatch(t, c)
+	expect.Expect(t, len(m.Path), 2)
+	expect.Expect(t, m.Path[0], helper.Key("labels"))
+	expect.Expect(t, m.Got, nil)
+}
+
+func TestCheckFlattenMismatch(t *testing.T) {
+	c := &Checker[*Container]{s, expandContainer, func(c *Container, d helper.ResourceData) {
+		flattenContainer(c, d)
+		d.Set("mounts", flatten.SliceFunc(c.Mounts, func(m *Mount, d helper.ResourceData) {
+			d.Set("target", m.Target)
+			d.Set("source", m.Target) // source is flattened from the wrong field
+			d.Set("size", m.Size)
+		}))
+	}}
+
+	m := firstMismatch(t, c)
+	expect.Expect(t, len(m.Path), 3)
+	expect.Expect(t, m.Path[0], helper.Key("mounts"))
+	expect.Expect(t, m.Path[2], helper.Key("source"))
+}
+
+func TestConfig(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		config := config(r, s)
+		if _, ok := config["image"]; !ok {
+			t.Fatalf("expected required attribute image to be set in %v", config)
+		}
+	}
+}
+
+func FuzzCheck(f *testing.F) {
+	c := &Checker[*Container]{s, expandContainer, flattenContainer}
+	c.Fuzz(f)
+}
+
+func TestCompareSetHash(t *testing.T) {
+	s := &schema.Schema{Type: schema.TypeSet, Elem: &schema.Schema{Type: schema.TypeString}}
+	negative := func(v interface{}) int { return -schema.HashString(v) }
+
+	m := compare(helper.Path{helper.Key("tags")}, schema.NewSet(negative, []interface{}{"x"}), nil, s)
+	expect.Expect(t, m.Path.String(), "tags."+strconv.Itoa(schema.HashString("x")))
+}
@@ -0,0 +1,19 @@
+package helper
+
+import "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+
+// ElemOf returns the schema of the elements of s, a list, set or map, which is
+// either a *schema.Schema or a *schema.Resource. Elements of maps without an
+// Elem are strings.
+func ElemOf(s *schema.Schema) interface{} {
+	if s.Elem == nil {
+		return &schema.Schema{Type: schema.TypeString}
+	}
+	return s.Elem
+}
+
+// Configurable reports whether s describes an attribute that can be set in a
+// configuration.
+func Configurable(s *schema.Schema) bool {
+	return s.Required || s.Optional || !s.Computed
+}
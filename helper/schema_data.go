@@ -0,0 +1,239 @@
+package helper
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// A SchemaError describes a value which does not conform to the schema of the
+// attribute it was set to.
+type SchemaError struct {
+	Path   Path        // full path of the attribute, up to the first set along it
+	Value  interface{} // value which was set
+	Reason string      // reason the value was rejected
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("helper: invalid value %#v for %q: %s", e.Value, e.Path.String(), e.Reason)
+}
+
+// SchemaMapData is a MapData bound to a schema. Values are validated and
+// normalized as they are set, so that mistakes are reported where they are
+// made, rather than when the flattened value is set on a schema.ResourceData.
+type SchemaMapData struct {
+	MapData
+	schema map[string]*schema.Schema
+	err    error
+}
+
+// NewMapData returns an empty SchemaMapData bound to the schema m.
+func NewMapData(m map[string]*schema.Schema) *SchemaMapData {
+	return &SchemaMapData{make(MapData), m, nil}
+}
+
+// Set validates and normalizes value against the schema of key before setting
+// it. A *SchemaError is returned if the key is not part of the schema or if the
+// value doesn't conform to it.
+//
+// Values are normalized to the types used by schema.ResourceData. Pointers are
+// dereferenced, integers of any size are converted to int and floats to
+// float64, slices and maps are converted to []interface{} and
+// map[string]interface{}, and values of TypeSet attributes are converted to a
+// *schema.Set using the hash function of the schema.
+func (sd *SchemaMapData) Set(key string, value interface{}) error {
+	p := ParsePath(key, sd.schema)
+	elem, err := elemAt(sd.schema, p)
+	if err == nil {
+		value, err = normalize(p, value, elem)
+	}
+	if err == nil {
+		err = sd.MapData.Set(key, value)
+	}
+	if err != nil && sd.err == nil {
+		sd.err = err
+	}
+	return err
+}
+
+// Err returns the first error returned by Set, which is useful when the caller
+// of Set is ignoring its errors.
+func (sd *SchemaMapData) Err() error {
+	return sd.err
+}
+
+var _ ResourceData = (*SchemaMapData)(nil)
+
+// elemAt returns the schema of the value at path p, which is either a
+// *schema.Schema or a *schema.Resource for elements of nested blocks.
+func elemAt(m map[string]*schema.Schema, p Path) (interface{}, error) {
+	var elem interface{} = &schema.Resource{Schema: m}
+	for i, step := range p {
+		switch e := elem.(type) {
+		case *schema.Resource:
+			s, ok := e.Schema[step.String()]
+			if _, isKey := step.(Key); !ok || !isKey {
+				return nil, &SchemaError{p[:i+1], nil, "unknown attribute"}
+			}
+			elem = s
+		case *schema.Schema:
+			if _, isKey := step.(Key); isKey {
+				return nil, &SchemaError{p[:i+1], nil, "unknown attribute"}
+			}
+			elem = ElemOf(e)
+		}
+	}
+	return elem, nil
+}
+
+// normalize validates v against elem, either a *schema.Schema or a
+// *schema.Resource, and converts it to the types used by schema.ResourceData.
+func normalize(p Path, v interface{}, elem interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil, nil
+	}
+	fail := func(reason string, args ...interface{}) (interface{}, error) {
+		return nil, &SchemaError{p, v, fmt.Sprintf(reason, args...)}
+	}
+
+	if r, ok := elem.(*schema.Resource); ok {
+		m, ok := stringMap(rv)
+		if !ok {
+			return fail("expected a map, got %s", rv.Type())
+		}
+		out := make(map[string]interface{}, len(m))
+		for k, item := range m {
+			s, ok := r.Schema[k]
+			if !ok {
+				return nil, &SchemaError{p.Key(k), item, "unknown attribute"}
+			}
+			var err error
+			if out[k], err = normalize(p.Key(k), item, s); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+
+	s := elem.(*schema.Schema)
+	switch s.Type {
+	case schema.TypeString:
+		if rv.Kind() != reflect.String {
+			return fail("expected a string, got %s", rv.Type())
+		}
+		return rv.String(), nil
+	case schema.TypeBool:
+		if rv.Kind() != reflect.Bool {
+			return fail("expected a bool, got %s", rv.Type())
+		}
+		return rv.Bool(), nil
+	case schema.TypeInt:
+		switch {
+		case rv.Kind() >= reflect.Int && rv.Kind() <= reflect.Int64:
+			if rv.Int() < math.MinInt || rv.Int() > math.MaxInt {
+				return fail("overflows int")
+			}
+			return int(rv.Int()), nil
+		case rv.Kind() >= reflect.Uint && rv.Kind() <= reflect.Uintptr:
+			if rv.Uint() > math.MaxInt {
+				return fail("overflows int")
+			}
+			return int(rv.Uint()), nil
+		}
+		return fail("expected an integer, got %s", rv.Type())
+	case schema.TypeFloat:
+		switch {
+		case rv.Kind() == reflect.Float32 || rv.Kind() == reflect.Float64:
+			return rv.Float(), nil
+		case rv.Kind() >= reflect.Int && rv.Kind() <= reflect.Int64:
+			return float64(rv.Int()), nil
+		case rv.Kind() >= reflect.Uint && rv.Kind() <= reflect.Uintptr:
+			return float64(rv.Uint()), nil
+		}
+		return fail("expected a number, got %s", rv.Type())
+	case schema.TypeMap:
+		m, ok := stringMap(rv)
+		if !ok {
+			return fail("expected a map, got %s", rv.Type())
+		}
+		out := make(map[string]interface{}, len(m))
+		for k, item := range m {
+			var err error
+			if out[k], err = normalize(p.MapKey(k), item, ElemOf(s)); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	case schema.TypeList, schema.TypeSet:
+		var items []interface{}
+		switch {
+		case rv.Type() == reflect.TypeOf(schema.Set{}):
+			set := rv.Addr().Interface().(*schema.Set)
+			items = set.List()
+		case rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array:
+			for i := 0; i < rv.Len(); i++ {
+				items = append(items, rv.Index(i).Interface())
+			}
+		default:
+			return fail("expected a list, got %s", rv.Type())
+		}
+		if s.MaxItems > 0 && len(items) > s.MaxItems {
+			return fail("expected at most %d elements, got %d", s.MaxItems, len(items))
+		}
+		out := make([]interface{}, len(items))
+		for i, item := range items {
+			var err error
+			if out[i], err = normalize(p.Index(i), item, ElemOf(s)); err != nil {
+				// A set element is identified by its hash, which can't be
+				// computed for an invalid element, so the error is reported
+				// at the set itself.
+				if se, ok := err.(*SchemaError); ok && s.Type == schema.TypeSet {
+					se.Path = p
+				}
+				return nil, err
+			}
+		}
+		if s.Type == schema.TypeSet {
+			return schema.NewSet(hashFunc(s), out), nil
+		}
+		return out, nil
+	}
+	return fail("unsupported schema type %s", s.Type)
+}
+
+// stringMap returns the elements of rv if it is a map with string keys.
+func stringMap(rv reflect.Value) (map[string]interface{}, bool) {
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	m := make(map[string]interface{}, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		m[iter.Key().String()] = iter.Value().Interface()
+	}
+	return m, true
+}
+
+// hashFunc returns the function used to hash elements of the set described by
+// s, the same way schema.ResourceData does.
+func hashFunc(s *schema.Schema) schema.SchemaSetFunc {
+	if s.Set != nil {
+		return s.Set
+	}
+	switch elem := ElemOf(s).(type) {
+	case *schema.Resource:
+		return schema.HashResource(elem)
+	case *schema.Schema:
+		return schema.HashSchema(elem)
+	}
+	return schema.HashString
+}
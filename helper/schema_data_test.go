@@ -0,0 +1,108 @@
+package helper
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+var testSchema = map[string]*schema.Schema{
+	"name":   {Type: schema.TypeString, Optional: true},
+	"count":  {Type: schema.TypeInt, Optional: true},
+	"ratio":  {Type: schema.TypeFloat, Optional: true},
+	"tags":   {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+	"labels": {Type: schema.TypeMap, Optional: true},
+	"block": {
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"port": {Type: schema.TypeInt, Optional: true},
+			},
+		},
+	},
+}
+
+func TestSchemaMapData(t *testing.T) {
+	d := NewMapData(testSchema)
+
+	name := "foo"
+	for key, value := range map[string]interface{}{
+		"name":   &name,
+		"count":  int64(3),
+		"ratio":  float32(0.5),
+		"tags":   []string{"a", "b"},
+		"labels": map[string]string{"a": "b"},
+		"block":  []map[string]interface{}{{"port": uint16(80)}},
+	} {
+		if err := d.Set(key, value); err != nil {
+			t.Fatalf("unexpected error setting %q: %s", key, err)
+		}
+	}
+
+	if v := d.Get("name"); v != "foo" {
+		t.Errorf("expected name to be %q, got %#v", "foo", v)
+	}
+	if v := d.Get("count"); v != 3 {
+		t.Errorf("expected count to be 3, got %#v", v)
+	}
+	if v := d.Get("ratio"); v != 0.5 {
+		t.Errorf("expected ratio to be 0.5, got %#v", v)
+	}
+	tags, ok := d.Get("tags").(*schema.Set)
+	if !ok || !tags.Equal(schema.NewSet(schema.HashSchema(&schema.Schema{Type: schema.TypeString}), []interface{}{"a", "b"})) {
+		t.Errorf("expected tags to be a set of a and b, got %#v", d.Get("tags"))
+	}
+	if v := d.Get("labels.a"); v != "b" {
+		t.Errorf("expected labels.a to be %q, got %#v", "b", v)
+	}
+	if v := d.Get("block.0.port"); v != 80 {
+		t.Errorf("expected block.0.port to be 80, got %#v", v)
+	}
+	if err := d.Set("block.0.port", 8080); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if d.Err() != nil {
+		t.Errorf("unexpected error: %s", d.Err())
+	}
+}
+
+func TestSchemaMapDataInvalid(t *testing.T) {
+	for key, test := range map[string]struct {
+		value interface{}
+		path  string
+	}{
+		"unknown":      {"x", "unknown"},
+		"name":         {1, "name"},
+		"count":        {"1", "count"},
+		"tags":         {[]int{1}, "tags"},
+		"labels":       {map[string]int{"a": 1}, "labels.a"},
+		"block":        {[]interface{}{map[string]interface{}{"x": 1}}, "block.0.x"},
+		"block.0.port": {"80", "block.0.port"},
+		"block.0.x":    {1, "block.0.x"},
+	} {
+		d := NewMapData(testSchema)
+		err := d.Set(key, test.value)
+		var se *SchemaError
+		if !errors.As(err, &se) {
+			t.Errorf("expected a *SchemaError setting %q, got %v", key, err)
+			continue
+		}
+		if se.Path.String() != test.path {
+			t.Errorf("expected error setting %q at %q, got %q", key, test.path, se.Path)
+		}
+		if d.Err() != err {
+			t.Errorf("expected Err to return %v, got %v", err, d.Err())
+		}
+	}
+}
+
+func TestSchemaMapDataMaxItems(t *testing.T) {
+	d := NewMapData(testSchema)
+	err := d.Set("block", []interface{}{map[string]interface{}{}, map[string]interface{}{}})
+	if err == nil {
+		t.Error("expected an error setting more elements than MaxItems")
+	}
+}
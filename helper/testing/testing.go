@@ -0,0 +1,201 @@
+// Package testing provides assertions for testing expanders and flatteners.
+//
+// Failed assertions report the paths of the values which differ, rather than
+// printing the nested []interface{} and map[string]interface{} values used by
+// Terraform as a whole.
+//
+//	Expected [map[mounts:...]] to equal [map[mounts:...]]
+//	  0.mounts.1606541327.target: "/mount/test" != "/mnt/test"
+//
+// As its name clashes with the standard library testing package, it is
+// usually imported with a different name.
+//
+//	import helpertest "github.com/alexkappa/terraform-plugin-helper/helper/testing"
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/alexkappa/terraform-plugin-helper/internal/testing/expect"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// A Difference is a leaf value which differs between two values.
+type Difference = expect.Difference
+
+// Diff returns the differences between x and y, walking maps, slices, structs
+// and pointers. Paths use the dotted format of Terraform, where elements of
+// slices are selected by index, elements of sets by hash code, and fields of
+// structs by name.
+//
+// Values are compared using reflect.DeepEqual, except for sets which are equal
+// if they hold the same elements.
+func Diff(x, y interface{}) []Difference {
+	return expect.Diff(x, y)
+}
+
+// Expect fails t if x differs from y, reporting the paths of the differences.
+func Expect(t testing.TB, x, y interface{}) bool {
+	t.Helper()
+	return expect.Expect(t, x, y)
+}
+
+// ExpectAttr fails t if the value of key held by d differs from v. If key holds
+// a set, v may be given as a []interface{} holding its elements.
+func ExpectAttr(t testing.TB, d helper.ResourceData, key string, v interface{}) bool {
+	t.Helper()
+	got := d.Get(key)
+	if s, ok := got.(*schema.Set); ok {
+		if items, ok := v.([]interface{}); ok {
+			v = schema.NewSet(s.F, items)
+		}
+	}
+	if diff := Diff(got, v); len(diff) > 0 {
+		t.Errorf("Expected %q to equal %v, got %v\n%s", key, v, got, expect.Format(diff))
+		return false
+	}
+	return true
+}
+
+// ExpectNoChange fails t if any of keys has changed.
+func ExpectNoChange(t testing.TB, d helper.ResourceData, keys ...string) bool {
+	t.Helper()
+	ok := true
+	for _, key := range keys {
+		if d.HasChange(key) {
+			o, n := d.GetChange(key)
+			t.Errorf("Expected %q not to change\n%s", key, expect.Format(Diff(o, n)))
+			ok = false
+		}
+	}
+	return ok
+}
+
+// ExpectFlatten fails t if the flattened elements got differ from want. Both
+// are normalized against the schema m of their elements first, so that the
+// comparison isn't affected by representation, such as an int64 in place of an
+// int, or a []interface{} in place of a set.
+func ExpectFlatten(t testing.TB, m map[string]*schema.Schema, got, want []interface{}) bool {
+	t.Helper()
+	g, err := normalize(m, got)
+	if err != nil {
+		t.Errorf("Unable to normalize flattened value: %s", err)
+		return false
+	}
+	w, err := normalize(m, want)
+	if err != nil {
+		t.Errorf("Unable to normalize expected value: %s", err)
+		return false
+	}
+	if diff := Diff(g, w); len(diff) > 0 {
+		t.Errorf("Expected %v to equal %v\n%s", got, want, expect.Format(diff))
+		return false
+	}
+	return true
+}
+
+func normalize(m map[string]*schema.Schema, in []interface{}) ([]interface{}, error) {
+	out := make([]interface{}, len(in))
+	s := map[string]*schema.Schema{
+		"elem": {Type: schema.TypeList, Elem: &schema.Resource{Schema: m}},
+	}
+	for i, elem := range in {
+		d := helper.NewMapData(s)
+		if err := d.Set("elem", []interface{}{elem}); err != nil {
+			return nil, err
+		}
+		out[i] = d.MapData["elem"].([]interface{})[0]
+	}
+	return out, nil
+}
+
+// Update controls whether ExpectGolden updates golden files rather than
+// comparing against them. It is set using the -update-golden flag. Test
+// binaries defining their own -update flag may use it instead.
+var Update = flag.Bool("update-golden", false, "update golden files")
+
+// updating reports whether golden files are to be updated, either through
+// Update or through an -update boolean flag defined by the test binary.
+func updating() bool {
+	if *Update {
+		return true
+	}
+	if f := flag.Lookup("update"); f != nil {
+		if g, ok := f.Value.(flag.Getter); ok {
+			b, _ := g.Get().(bool)
+			return b
+		}
+	}
+	return false
+}
+
+// ExpectGolden fails t if the JSON representation of got differs from the
+// contents of the golden file testdata/name.golden. Sets are represented by a
+// list of their elements.
+//
+// If the -update-golden flag is given, the golden file is written instead.
+func ExpectGolden(t testing.TB, name string, got interface{}) bool {
+	t.Helper()
+	b, err := json.MarshalIndent(plain(got), "", "  ")
+	if err != nil {
+		t.Errorf("Unable to marshal %v: %s", got, err)
+		return false
+	}
+	b = append(b, '\n')
+
+	file := filepath.Join("testdata", name+".golden")
+	if updating() {
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Error(err)
+			return false
+		}
+		if err := os.WriteFile(file, b, 0644); err != nil {
+			t.Error(err)
+			return false
+		}
+		return true
+	}
+
+	want, err := os.ReadFile(file)
+	if err != nil {
+		t.Errorf("Unable to read golden file, run with -update-golden to create it: %s", err)
+		return false
+	}
+	if bytes.Equal(b, want) {
+		return true
+	}
+	var x, y interface{}
+	json.Unmarshal(b, &x)
+	json.Unmarshal(want, &y)
+	t.Errorf("Expected %s to match golden file %s\n%s", name, file, expect.Format(Diff(x, y)))
+	return false
+}
+
+// plain converts sets to lists, which can be marshaled.
+func plain(v interface{}) interface{} {
+	switch v := v.(type) {
+	case *schema.Set:
+		return plain(v.List())
+	case helper.MapData:
+		return plain(map[string]interface{}(v))
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[k] = plain(item)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = plain(item)
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,135 @@
+package testing
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/helper"
+	"github.com/alexkappa/terraform-plugin-helper/helper/resourcetest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// recorder captures the failures reported to it.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Error(args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprint(args...))
+}
+
+var s = map[string]*schema.Schema{
+	"name": {Type: schema.TypeString, Optional: true},
+	"size": {Type: schema.TypeInt, Optional: true},
+	"tags": {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+	"spec": {
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"image": {Type: schema.TypeString, Optional: true},
+			},
+		},
+	},
+}
+
+func TestExpect(t *testing.T) {
+	r := &recorder{TB: t}
+	ok := Expect(r,
+		[]interface{}{map[string]interface{}{"spec": []interface{}{map[string]interface{}{"image": "nginx"}}}},
+		[]interface{}{map[string]interface{}{"spec": []interface{}{map[string]interface{}{"image": "httpd"}}}},
+	)
+	if ok || len(r.errors) != 1 || !strings.Contains(r.errors[0], `0.spec.0.image: "nginx" != "httpd"`) {
+		t.Errorf("expected failure reporting the path of the difference, got %q", r.errors)
+	}
+}
+
+func TestExpectAttr(t *testing.T) {
+	d, err := resourcetest.NewCreate(s, map[string]interface{}{
+		"name": "foo",
+		"tags": []interface{}{"a", "b"},
+		"spec": []interface{}{map[string]interface{}{"image": "nginx"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ExpectAttr(t, d, "name", "foo")
+	ExpectAttr(t, d, "tags", []interface{}{"b", "a"})
+	ExpectAttr(t, d, "spec.0.image", "nginx")
+
+	r := &recorder{TB: t}
+	if ExpectAttr(r, d, "spec.0.image", "httpd") || len(r.errors) != 1 {
+		t.Errorf("expected a failure, got %q", r.errors)
+	}
+}
+
+func TestExpectNoChange(t *testing.T) {
+	d, err := resourcetest.NewUpdate(s, map[string]interface{}{
+		"name": "foo",
+		"size": 1,
+	}, map[string]interface{}{
+		"name": "foo",
+		"size": 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ExpectNoChange(t, d, "name")
+
+	r := &recorder{TB: t}
+	if ExpectNoChange(r, d, "name", "size") || len(r.errors) != 1 || !strings.Contains(r.errors[0], "1 != 2") {
+		t.Errorf("expected a failure for size, got %q", r.errors)
+	}
+}
+
+func TestExpectFlatten(t *testing.T) {
+	m := s["spec"].Elem.(*schema.Resource).Schema
+	ExpectFlatten(t, s, []interface{}{
+		helper.MapData{"size": int64(1), "tags": []string{"a", "b"}},
+	}, []interface{}{
+		map[string]interface{}{"size": 1, "tags": []interface{}{"b", "a"}},
+	})
+
+	r := &recorder{TB: t}
+	if ExpectFlatten(r, m, []interface{}{
+		map[string]interface{}{"image": 1},
+	}, nil) || len(r.errors) != 1 {
+		t.Errorf("expected a failure normalizing an invalid value, got %q", r.errors)
+	}
+}
+
+func TestExpectGolden(t *testing.T) {
+	ExpectGolden(t, "flatten", []interface{}{
+		map[string]interface{}{
+			"name": "foo",
+			"tags": schema.NewSet(schema.HashString, []interface{}{"a", "b"}),
+		},
+	})
+
+	r := &recorder{TB: t}
+	if updating() {
+		return
+	}
+	if ExpectGolden(r, "flatten", []interface{}{map[string]interface{}{"name": "bar"}}) || len(r.errors) != 1 {
+		t.Errorf("expected a failure, got %q", r.errors)
+	}
+}
+
+func TestUpdating(t *testing.T) {
+	Expect(t, updating(), *Update)
+
+	fs := flag.CommandLine
+	defer func() { flag.CommandLine = fs }()
+	flag.CommandLine = flag.NewFlagSet("test", flag.ContinueOnError)
+	flag.Bool("update", true, "")
+	Expect(t, updating(), true)
+}
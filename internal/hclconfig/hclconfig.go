@@ -0,0 +1,128 @@
+// Package hclconfig decodes the body of a resource, written in the native
+// syntax of HCL, into the raw form of a configuration accepted by
+// schema.TestResourceDataRaw.
+//
+// HCL always represents values using the cty package of zclconf, regardless of
+// the version of the SDK in use.
+package hclconfig
+
+import (
+	"fmt"
+
+	"github.com/alexkappa/terraform-plugin-helper/internal/value"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Decode parses src, the body of a resource with schema s, and returns its
+// attributes and blocks in their raw form. The body is decoded with the spec of
+// the resource's core configuration schema, the same way as Terraform decodes
+// it. Blocks are decoded into a list of maps, and attributes which aren't set
+// are omitted.
+//
+// If s has no attribute named "id", the body may set one.
+func Decode(filename, src string, s map[string]*schema.Schema) (map[string]interface{}, error) {
+	file, diags := hclsyntax.ParseConfig([]byte(src), filename, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	spec, err := decoderSpec(s)
+	if err != nil {
+		return nil, err
+	}
+	v, diags := hcldec.Decode(file.Body, spec, nil)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return objectRaw(v, s)
+}
+
+// objectRaw converts v, a body decoded with the spec of s, to its raw form.
+// Blocks which aren't present are omitted, the same way as attributes which
+// aren't set.
+func objectRaw(v cty.Value, s map[string]*schema.Schema) (map[string]interface{}, error) {
+	out := make(map[string]interface{})
+	for it := v.ElementIterator(); it.Next(); {
+		k, e := it.Element()
+		name := k.AsString()
+		if r := block(s[name]); r != nil {
+			if e.IsNull() || !e.IsKnown() || e.LengthInt() == 0 {
+				continue
+			}
+			var blocks []interface{}
+			for it := e.ElementIterator(); it.Next(); {
+				_, b := it.Element()
+				m, err := objectRaw(b, r.Schema)
+				if err != nil {
+					return nil, err
+				}
+				blocks = append(blocks, m)
+			}
+			out[name] = blocks
+			continue
+		}
+		item, err := toRaw(e)
+		if err != nil {
+			return nil, err
+		}
+		if item != nil {
+			out[name] = item
+		}
+	}
+	return out, nil
+}
+
+// block returns the resource nested within the blocks of s, or nil if s isn't
+// decoded from blocks.
+func block(s *schema.Schema) *schema.Resource {
+	if s == nil || (s.Type != schema.TypeList && s.Type != schema.TypeSet) {
+		return nil
+	}
+	r, _ := s.Elem.(*schema.Resource)
+	return r
+}
+
+// toRaw converts v, the value of an attribute, to its raw form. Null values are
+// converted to nil, and are omitted from the maps holding them.
+func toRaw(v cty.Value) (interface{}, error) {
+	if v.IsNull() || !v.IsKnown() {
+		return nil, nil
+	}
+	t := v.Type()
+	switch {
+	case t.IsObjectType(), t.IsMapType():
+		out := make(map[string]interface{}, v.LengthInt())
+		for it := v.ElementIterator(); it.Next(); {
+			k, e := it.Element()
+			item, err := toRaw(e)
+			if err != nil {
+				return nil, err
+			}
+			if item != nil {
+				out[k.AsString()] = item
+			}
+		}
+		return out, nil
+	case t.IsListType(), t.IsSetType(), t.IsTupleType():
+		out := make([]interface{}, 0, v.LengthInt())
+		for it := v.ElementIterator(); it.Next(); {
+			_, e := it.Element()
+			item, err := toRaw(e)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, item)
+		}
+		return out, nil
+	case t == cty.String:
+		return v.AsString(), nil
+	case t == cty.Bool:
+		return v.True(), nil
+	case t == cty.Number:
+		return value.Number(v.AsBigFloat()), nil
+	}
+	return nil, fmt.Errorf("hclconfig: unsupported type %s", t.FriendlyName())
+}
@@ -0,0 +1,90 @@
+package hclconfig
+
+import (
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/internal/testing/expect"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDecode(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"name":   {Type: schema.TypeString},
+		"size":   {Type: schema.TypeInt},
+		"ratio":  {Type: schema.TypeFloat},
+		"ports":  {Type: schema.TypeList, Elem: &schema.Schema{Type: schema.TypeInt}},
+		"labels": {Type: schema.TypeMap},
+		"mount": {Type: schema.TypeSet, MaxItems: 2, Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"target": {Type: schema.TypeString},
+				"option": {Type: schema.TypeList, Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{"no_copy": {Type: schema.TypeBool}},
+				}},
+			},
+		}},
+	}
+
+	raw, err := Decode("test.tf", `
+		id     = "i-123"
+		name   = "foo"
+		size   = 3
+		ratio  = 1.5
+		ports  = [80, 443]
+		labels = { env = "prod" }
+
+		mount {
+			target = "/mnt"
+			option {
+				no_copy = true
+			}
+		}
+	`, s)
+	expect.Expect(t, err, nil)
+	expect.Expect(t, raw, map[string]interface{}{
+		"id":     "i-123",
+		"name":   "foo",
+		"size":   3,
+		"ratio":  1.5,
+		"ports":  []interface{}{80, 443},
+		"labels": map[string]interface{}{"env": "prod"},
+		"mount": []interface{}{
+			map[string]interface{}{
+				"target": "/mnt",
+				"option": []interface{}{
+					map[string]interface{}{"no_copy": true},
+				},
+			},
+		},
+	})
+
+	raw, err = Decode("test.tf", ``, s)
+	expect.Expect(t, err, nil)
+	expect.Expect(t, raw, map[string]interface{}{})
+
+	_, err = Decode("test.tf", `unknown = 1`, s)
+	if err == nil {
+		t.Error("Expected an error decoding an attribute missing from the schema")
+	}
+}
+
+func TestDecodeCoreSchema(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"mount": {Type: schema.TypeList, Required: true, Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{"target": {Type: schema.TypeString, Required: true}},
+		}},
+		"status": {Type: schema.TypeList, Computed: true, Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{"state": {Type: schema.TypeString, Computed: true}},
+		}},
+	}
+
+	_, err := Decode("test.tf", "mount {}", s)
+	if err == nil {
+		t.Error("Expected an error decoding a block missing a required attribute")
+	}
+
+	raw, err := Decode("test.tf", "mount {\n  target = \"/mnt\"\n}", s)
+	expect.Expect(t, err, nil)
+	expect.Expect(t, raw, map[string]interface{}{
+		"mount": []interface{}{map[string]interface{}{"target": "/mnt"}},
+	})
+}
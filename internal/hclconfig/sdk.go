@@ -0,0 +1,13 @@
+package hclconfig
+
+import (
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// decoderSpec returns the spec of the core configuration schema of a resource
+// with schema s. It is kept apart as the core schema isn't exported by every
+// version of the SDK.
+func decoderSpec(s map[string]*schema.Schema) (hcldec.Spec, error) {
+	return (&schema.Resource{Schema: s}).CoreConfigSchema().DecoderSpec(), nil
+}
@@ -0,0 +1,39 @@
+// Package tag parses the `tf:"..."` struct tags used by the reflective expand
+// and flatten helpers.
+package tag
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Name is the struct tag key looked up on struct fields.
+const Name = "tf"
+
+// Options holds the comma separated options following the name in a tag.
+type Options []string
+
+// Contains reports whether the options include opt.
+func (o Options) Contains(opt string) bool {
+	for _, s := range o {
+		if s == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse returns the attribute name and options of the struct field f. The
+// boolean result is false if the field has no tag or is explicitly ignored
+// with `tf:"-"`.
+func Parse(f reflect.StructField) (string, Options, bool) {
+	v, ok := f.Tag.Lookup(Name)
+	if !ok || v == "-" {
+		return "", nil, false
+	}
+	parts := strings.Split(v, ",")
+	if parts[0] == "" {
+		return "", nil, false
+	}
+	return parts[0], Options(parts[1:]), true
+}
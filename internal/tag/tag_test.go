@@ -0,0 +1,33 @@
+package tag
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/internal/testing/expect"
+)
+
+func TestParse(t *testing.T) {
+	typ := reflect.TypeOf(struct {
+		A string `tf:"a"`
+		B string `tf:"b,omitempty"`
+		C string `tf:"-"`
+		D string
+	}{})
+
+	name, opts, ok := Parse(typ.Field(0))
+	expect.Expect(t, name, "a")
+	expect.Expect(t, len(opts), 0)
+	expect.Expect(t, ok, true)
+
+	name, opts, ok = Parse(typ.Field(1))
+	expect.Expect(t, name, "b")
+	expect.Expect(t, opts.Contains("omitempty"), true)
+	expect.Expect(t, ok, true)
+
+	_, _, ok = Parse(typ.Field(2))
+	expect.Expect(t, ok, false)
+
+	_, _, ok = Parse(typ.Field(3))
+	expect.Expect(t, ok, false)
+}
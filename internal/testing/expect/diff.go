@@ -0,0 +1,167 @@
+package expect
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alexkappa/terraform-plugin-helper/internal/value"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// A Difference is a leaf value which differs between two values.
+type Difference struct {
+	Path string      // dotted path of the leaf, empty for the root
+	X, Y interface{} // values of the leaf, nil if missing
+}
+
+func (d Difference) String() string {
+	if d.Path == "" {
+		return fmt.Sprintf("%#v != %#v", d.X, d.Y)
+	}
+	return fmt.Sprintf("%s: %#v != %#v", d.Path, d.X, d.Y)
+}
+
+// Diff returns the differences between x and y, walking maps, slices, structs
+// and pointers. Paths use the dotted format of Terraform, where elements of
+// slices are selected by index, elements of sets by hash code, and fields of
+// structs by name.
+//
+// Values are compared using reflect.DeepEqual, except for sets which are equal
+// if they hold the same elements.
+func Diff(x, y interface{}) []Difference {
+	var out []Difference
+	diff(nil, reflect.ValueOf(x), reflect.ValueOf(y), &out)
+	return out
+}
+
+var setType = reflect.TypeOf((*schema.Set)(nil))
+
+func diff(p []string, x, y reflect.Value, out *[]Difference) {
+	x, y = elem(x), elem(y)
+	if !x.IsValid() || !y.IsValid() || x.Type() != y.Type() {
+		if x.IsValid() || y.IsValid() {
+			add(p, x, y, out)
+		}
+		return
+	}
+	if x.CanInterface() && y.CanInterface() && x.Type() != setType && reflect.DeepEqual(x.Interface(), y.Interface()) {
+		return
+	}
+	switch x.Kind() {
+	case reflect.Ptr:
+		if x.Type() == setType && !x.IsNil() && !y.IsNil() {
+			diffSets(p, x.Interface().(*schema.Set), y.Interface().(*schema.Set), out)
+			return
+		}
+		if x.IsNil() || y.IsNil() {
+			add(p, x, y, out)
+			return
+		}
+		diff(p, x.Elem(), y.Elem(), out)
+	case reflect.Struct:
+		for i := 0; i < x.NumField(); i++ {
+			diff(step(p, x.Type().Field(i).Name), x.Field(i), y.Field(i), out)
+		}
+	case reflect.Map:
+		if x.IsNil() != y.IsNil() {
+			add(p, x, y, out)
+			return
+		}
+		for _, k := range mapKeys(x, y) {
+			diff(step(p, fmt.Sprint(k)), x.MapIndex(k), y.MapIndex(k), out)
+		}
+	case reflect.Slice, reflect.Array:
+		if x.Kind() == reflect.Slice && x.IsNil() != y.IsNil() {
+			add(p, x, y, out)
+			return
+		}
+		for i := 0; i < x.Len() || i < y.Len(); i++ {
+			var xi, yi reflect.Value
+			if i < x.Len() {
+				xi = x.Index(i)
+			}
+			if i < y.Len() {
+				yi = y.Index(i)
+			}
+			diff(step(p, strconv.Itoa(i)), xi, yi, out)
+		}
+	default:
+		if fmt.Sprintf("%#v", held(x)) != fmt.Sprintf("%#v", held(y)) {
+			add(p, x, y, out)
+		}
+	}
+}
+
+func diffSets(p []string, x, y *schema.Set, out *[]Difference) {
+	xs, ys := codes(x), codes(y)
+	keys := make([]int, 0, len(xs)+len(ys))
+	for code := range xs {
+		keys = append(keys, code)
+	}
+	for code := range ys {
+		if _, ok := xs[code]; !ok {
+			keys = append(keys, code)
+		}
+	}
+	sort.Ints(keys)
+	for _, code := range keys {
+		diff(step(p, strconv.Itoa(code)), reflect.ValueOf(xs[code]), reflect.ValueOf(ys[code]), out)
+	}
+}
+
+func codes(s *schema.Set) map[int]interface{} {
+	out := make(map[int]interface{})
+	for _, item := range s.List() {
+		out[value.HashCode(s, item)] = item
+	}
+	return out
+}
+
+// elem unwraps interfaces.
+func elem(v reflect.Value) reflect.Value {
+	for v.IsValid() && v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func mapKeys(x, y reflect.Value) []reflect.Value {
+	seen := make(map[interface{}]bool)
+	var keys []reflect.Value
+	for _, k := range append(x.MapKeys(), y.MapKeys()...) {
+		if !seen[k.Interface()] {
+			seen[k.Interface()] = true
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	return keys
+}
+
+func step(p []string, s string) []string {
+	return append(p[:len(p):len(p)], s)
+}
+
+func add(p []string, x, y reflect.Value, out *[]Difference) {
+	*out = append(*out, Difference{strings.Join(p, "."), held(x), held(y)})
+}
+
+// held returns the value held by v, or its string representation if it's an
+// unexported field.
+func held(v reflect.Value) interface{} {
+	switch {
+	case !v.IsValid():
+		return nil
+	case v.CanInterface():
+		return v.Interface()
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,58 @@
+package expect
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDiff(t *testing.T) {
+	type inner struct{ Name string }
+	type outer struct {
+		Inner *inner
+		Tags  []string
+	}
+	for _, test := range []struct {
+		x, y   interface{}
+		expect []Difference
+	}{
+		{"a", "a", nil},
+		{"a", "b", []Difference{{"", "a", "b"}}},
+		{1, "1", []Difference{{"", 1, "1"}}},
+		{
+			[]interface{}{map[string]interface{}{"a": []interface{}{1, 2}}},
+			[]interface{}{map[string]interface{}{"a": []interface{}{1, 3}}},
+			[]Difference{{"0.a.1", 2, 3}},
+		},
+		{
+			map[string]interface{}{"a": 1},
+			map[string]interface{}{"b": 1},
+			[]Difference{{"a", 1, nil}, {"b", nil, 1}},
+		},
+		{
+			[]int{1},
+			[]int{1, 2},
+			[]Difference{{"1", nil, 2}},
+		},
+		{
+			outer{&inner{"a"}, []string{"x"}},
+			outer{&inner{"b"}, nil},
+			[]Difference{{"Inner.Name", "a", "b"}, {"Tags", []string{"x"}, []string(nil)}},
+		},
+		{
+			schema.NewSet(schema.HashString, []interface{}{"a", "b"}),
+			schema.NewSet(schema.HashString, []interface{}{"b", "a"}),
+			nil,
+		},
+		{
+			schema.NewSet(schema.HashString, []interface{}{"a"}),
+			schema.NewSet(schema.HashString, nil),
+			[]Difference{{"3904355907", "a", nil}},
+		},
+	} {
+		if d := Diff(test.x, test.y); !reflect.DeepEqual(d, test.expect) {
+			t.Errorf("Diff(%#v, %#v) = %v, expected %v", test.x, test.y, d, test.expect)
+		}
+	}
+}
@@ -1,17 +1,28 @@
 package expect
 
 import (
-	"reflect"
+	"strings"
 	"testing"
 )
 
 // Expect performs an assertion that expects x to equal y. The assertion is
-// performed using reflect.DeepEqual.
-func Expect(t *testing.T, x, y interface{}) bool {
+// performed using Diff, and the paths of the differing values are reported.
+func Expect(t testing.TB, x, y interface{}) bool {
 	t.Helper()
-	if !reflect.DeepEqual(x, y) {
-		t.Errorf("Expected %v to equal %v\n", x, y)
+	if d := Diff(x, y); len(d) > 0 {
+		t.Errorf("Expected %v to equal %v\n%s", x, y, Format(d))
 		return false
 	}
 	return true
 }
+
+// Format formats differences one per line.
+func Format(d []Difference) string {
+	var b strings.Builder
+	for _, d := range d {
+		b.WriteString("  ")
+		b.WriteString(d.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package value
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+var rawMap = reflect.TypeOf(map[string]interface{}(nil))
+
+// Indirect dereferences v until it isn't a pointer, returning nil for a nil
+// pointer. Sets and big floats, which are held by pointer, are returned as is.
+func Indirect(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		switch v.(type) {
+		case *schema.Set, *big.Float:
+			return v
+		}
+		rv = rv.Elem()
+		v = rv.Interface()
+	}
+	return v
+}
+
+// Map returns v as a map[string]interface{}, converting maps of strings and
+// named map types such as helper.MapData.
+func Map(v interface{}) (map[string]interface{}, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[string]string:
+		out := make(map[string]interface{}, len(v))
+		for k, s := range v {
+			out[k] = s
+		}
+		return out, true
+	}
+	if rv := reflect.ValueOf(v); rv.IsValid() && rv.Type().ConvertibleTo(rawMap) {
+		return rv.Convert(rawMap).Interface().(map[string]interface{}), true
+	}
+	return nil, false
+}
+
+// List returns v as a []interface{}, converting sets and slices of strings.
+func List(v interface{}) ([]interface{}, bool) {
+	switch v := v.(type) {
+	case *schema.Set:
+		return v.List(), true
+	case []interface{}:
+		return v, true
+	case []string:
+		out := make([]interface{}, len(v))
+		for i, s := range v {
+			out[i] = s
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+// BigFloat converts v, which may be any integer or float, to a *big.Float. NaN
+// and infinite floats can't be converted.
+func BigFloat(v interface{}) (*big.Float, bool) {
+	if f, ok := v.(*big.Float); ok {
+		return f, true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Float).SetInt64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Float).SetUint64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, false
+		}
+		return big.NewFloat(f), true
+	}
+	return nil, false
+}
+
+// Number converts f to an int if it is a whole number in its range, or a
+// float64 otherwise, the way numbers are held by schema.ResourceData.
+func Number(f *big.Float) interface{} {
+	if f.IsInt() {
+		if i, acc := f.Int64(); acc == big.Exact && i >= math.MinInt && i <= math.MaxInt {
+			return int(i)
+		}
+	}
+	n, _ := f.Float64()
+	return n
+}
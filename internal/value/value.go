@@ -0,0 +1,104 @@
+// Package value navigates values in Terraform's internal representation, made
+// of nested maps, lists and sets, using the dotted paths of
+// schema.ResourceData.
+package value
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// Lookup resolves the dotted path key within v. Nested maps are accessed by
+// key, lists by index and sets by the hash code of their elements. The "#" and
+// "%" keys hold the number of elements of a list, set or map.
+func Lookup(v interface{}, key string) (interface{}, bool) {
+	for _, part := range strings.Split(key, ".") {
+		var ok bool
+		if v, ok = step(v, part); !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func step(v interface{}, part string) (interface{}, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if e, ok := v[part]; ok || part != "%" {
+			return e, ok
+		}
+		return len(v), true
+	case []interface{}:
+		if part == "#" {
+			return len(v), true
+		}
+		i, err := strconv.Atoi(part)
+		if err != nil || i < 0 || i >= len(v) {
+			return nil, false
+		}
+		return v[i], true
+	case *schema.Set:
+		if part == "#" {
+			return v.Len(), true
+		}
+		for _, e := range v.List() {
+			if strconv.Itoa(HashCode(v, e)) == part {
+				return e, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// SetIn returns a copy of v with the value at path p replaced by value. Only
+// the maps, lists and sets along p are copied.
+func SetIn(v interface{}, p []string, value interface{}) (interface{}, error) {
+	if len(p) == 0 {
+		return value, nil
+	}
+	switch v := v.(type) {
+	case []interface{}:
+		i, err := strconv.Atoi(p[0])
+		if err != nil || i < 0 || i >= len(v) {
+			return nil, fmt.Errorf("index %q out of range", p[0])
+		}
+		out := make([]interface{}, len(v))
+		copy(out, v)
+		out[i], err = SetIn(v[i], p[1:], value)
+		return out, err
+	case *schema.Set:
+		out := v.List()
+		for i, item := range out {
+			if strconv.Itoa(HashCode(v, item)) == p[0] {
+				var err error
+				if out[i], err = SetIn(item, p[1:], value); err != nil {
+					return nil, err
+				}
+				return schema.NewSet(v.F, out), nil
+			}
+		}
+		return nil, fmt.Errorf("no set element with hash %q", p[0])
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[k] = item
+		}
+		var err error
+		out[p[0]], err = SetIn(v[p[0]], p[1:], value)
+		return out, err
+	}
+	return nil, fmt.Errorf("can't set %q on %T", p[0], v)
+}
+
+// HashCode returns the hash code of item as it appears in the path of set
+// elements.
+func HashCode(s *schema.Set, item interface{}) int {
+	code := s.F(item)
+	if code < 0 {
+		code = -code
+	}
+	return code
+}
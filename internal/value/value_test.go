@@ -0,0 +1,80 @@
+package value_test
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/internal/testing/expect"
+	"github.com/alexkappa/terraform-plugin-helper/internal/value"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestLookup(t *testing.T) {
+	v := map[string]interface{}{
+		"list": []interface{}{
+			map[string]interface{}{"foo": "bar"},
+		},
+		"set": schema.NewSet(schema.HashString, []interface{}{"x"}),
+	}
+	for key, want := range map[string]interface{}{
+		"list.#":     1,
+		"list.0.%":   1,
+		"list.0.foo": "bar",
+		"set.#":      1,
+		"set." + strconv.Itoa(schema.HashString("x")): "x",
+	} {
+		got, ok := value.Lookup(v, key)
+		expect.Expect(t, ok, true)
+		expect.Expect(t, got, want)
+	}
+	_, ok := value.Lookup(v, "list.1")
+	expect.Expect(t, ok, false)
+}
+
+func TestSetIn(t *testing.T) {
+	v := map[string]interface{}{
+		"list": []interface{}{
+			map[string]interface{}{"foo": "bar"},
+		},
+	}
+	out, err := value.SetIn(v, []string{"list", "0", "foo"}, "baz")
+	expect.Expect(t, err, nil)
+	expect.Expect(t, out, map[string]interface{}{
+		"list": []interface{}{
+			map[string]interface{}{"foo": "baz"},
+		},
+	})
+	got, _ := value.Lookup(v, "list.0.foo")
+	expect.Expect(t, got, "bar")
+
+	_, err = value.SetIn(v, []string{"list", "foo"}, "baz")
+	expect.Expect(t, err != nil, true)
+}
+
+func TestRaw(t *testing.T) {
+	s := "foo"
+	expect.Expect(t, value.Indirect(&s), "foo")
+	expect.Expect(t, value.Indirect((*string)(nil)), nil)
+
+	type named map[string]interface{}
+	m, ok := value.Map(named{"foo": "bar"})
+	expect.Expect(t, ok, true)
+	expect.Expect(t, m, map[string]interface{}{"foo": "bar"})
+	m, _ = value.Map(map[string]string{"foo": "bar"})
+	expect.Expect(t, m, map[string]interface{}{"foo": "bar"})
+	_, ok = value.Map("foo")
+	expect.Expect(t, ok, false)
+
+	l, _ := value.List(schema.NewSet(schema.HashString, []interface{}{"x"}))
+	expect.Expect(t, l, []interface{}{"x"})
+	l, _ = value.List([]string{"x"})
+	expect.Expect(t, l, []interface{}{"x"})
+
+	f, _ := value.BigFloat(uint8(3))
+	expect.Expect(t, value.Number(f), 3)
+	f, _ = value.BigFloat(1.5)
+	expect.Expect(t, value.Number(f), 1.5)
+	_, ok = value.BigFloat(math.NaN())
+	expect.Expect(t, ok, false)
+}
@@ -0,0 +1,14 @@
+// Package sdkv2 is the root of a module holding the helper packages built
+// against version 2 of the Terraform Plugin SDK.
+//
+// The two versions of the SDK can't be linked into the same binary, so the
+// packages of the parent module, which use version 1, are copied by gen.go
+// with their imports rewritten. Files which differ between the versions, as
+// well as the functions returning diag.Diagnostics, are maintained by hand.
+//
+// Import the packages of this module in place of those of the parent module.
+//
+//	import "github.com/alexkappa/terraform-plugin-helper/sdkv2/helper/expand"
+package sdkv2
+
+//go:generate go run gen.go
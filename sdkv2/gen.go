@@ -0,0 +1,153 @@
+//go:build ignore
+// +build ignore
+
+// gen.go copies the helper packages of the parent module, rewriting their
+// imports to use version 2 of the Terraform Plugin SDK.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// packages are the directories of the parent module which are copied.
+var packages = []string{
+	"helper",
+	"helper/expand",
+	"helper/flatten",
+	"helper/lifecycle",
+	"helper/resourcetest",
+	"helper/roundtrip",
+	"helper/testing",
+	"internal/hclconfig",
+	"internal/tag",
+	"internal/testing/mock/aws/aws-sdk-go/service/ec2",
+	"internal/testing/expect",
+	"internal/value",
+}
+
+// skip are files which can't be copied, as they use APIs which differ between
+// versions of the SDK. They are ported by hand.
+var skip = map[string]bool{
+	"helper/lifecycle/sdk.go":    true,
+	"helper/resourcetest/sdk.go": true,
+	"internal/hclconfig/sdk.go":  true,
+}
+
+// hcl are the directories whose values come from HCL, which uses the cty
+// package of zclconf regardless of the version of the SDK. Their cty imports
+// are kept.
+var hcl = map[string]bool{
+	"internal/hclconfig": true,
+}
+
+var (
+	imports = strings.NewReplacer(
+		`"github.com/alexkappa/terraform-plugin-helper/`, `"github.com/alexkappa/terraform-plugin-helper/sdkv2/`,
+		`"github.com/hashicorp/terraform-plugin-sdk/`, `"github.com/hashicorp/terraform-plugin-sdk/v2/`,
+		`"github.com/zclconf/go-cty/cty"`, `"github.com/hashicorp/go-cty/cty"`,
+	)
+	hclImports = strings.NewReplacer(
+		`"github.com/alexkappa/terraform-plugin-helper/`, `"github.com/alexkappa/terraform-plugin-helper/sdkv2/`,
+		`"github.com/hashicorp/terraform-plugin-sdk/`, `"github.com/hashicorp/terraform-plugin-sdk/v2/`,
+	)
+)
+
+const header = "// Code generated by gen.go from %s. DO NOT EDIT.\n\n"
+
+// out is the directory the packages are copied to, which is the module itself
+// unless the copy is checked against it.
+var out = flag.String("o", ".", "output directory")
+
+func main() {
+	flag.Parse()
+	for _, pkg := range packages {
+		if err := clean(filepath.Join(*out, pkg)); err != nil {
+			log.Fatal(err)
+		}
+		files, err := filepath.Glob(filepath.Join("..", pkg, "*.go"))
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, file := range files {
+			name := filepath.ToSlash(filepath.Join(pkg, filepath.Base(file)))
+			if skip[name] || filepath.Base(file) == "gen.go" {
+				continue
+			}
+			r := imports
+			if hcl[pkg] {
+				r = hclImports
+			}
+			if err := copyFile(file, filepath.Join(*out, name), r); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if err := copyDir(filepath.Join("..", pkg, "testdata"), filepath.Join(*out, pkg, "testdata")); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// clean removes the files generated previously in dir.
+func clean(dir string) error {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, file := range files {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if bytes.HasPrefix(b, []byte("// Code generated by gen.go")) {
+			if err := os.Remove(file); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dst string, imports *strings.Replacer) error {
+	b, err := ioutil.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	var lines []string
+	for _, line := range strings.Split(imports.Replace(string(b)), "\n") {
+		if !strings.HasPrefix(line, "//go:generate") {
+			lines = append(lines, line)
+		}
+	}
+	s := fmt.Sprintf(header, filepath.ToSlash(src)) + strings.Join(lines, "\n")
+
+	out, err := format.Source([]byte(s))
+	if err != nil {
+		return fmt.Errorf("%s: %s", src, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(dst, out, 0644)
+}
+
+func copyDir(src, dst string) error {
+	files, _ := filepath.Glob(filepath.Join(src, "*"))
+	for _, file := range files {
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(dst, 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(dst, filepath.Base(file)), b, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
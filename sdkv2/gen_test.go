@@ -0,0 +1,74 @@
+package sdkv2
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerated checks that the packages copied by gen.go are up to date, by
+// copying them again into a temporary directory and comparing both trees.
+func TestGenerated(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not installed")
+	}
+	dir := t.TempDir()
+	out, err := exec.Command("go", "run", "gen.go", "-o", dir).CombinedOutput()
+	if err != nil {
+		t.Fatalf("go run gen.go: %s\n%s", err, out)
+	}
+
+	want := generated(t, dir)
+	got := generated(t, ".")
+	for name, b := range want {
+		if g, ok := got[name]; !ok {
+			t.Errorf("%s is missing, run go generate", name)
+		} else if !bytes.Equal(g, b) {
+			t.Errorf("%s is out of date, run go generate", name)
+		}
+	}
+	for name := range got {
+		if _, ok := want[name]; !ok {
+			t.Errorf("%s is no longer generated, run go generate", name)
+		}
+	}
+}
+
+// generated returns the contents of the files generated by gen.go under dir,
+// which are the Go files holding its header and the files of testdata.
+func generated(t *testing.T, dir string) map[string][]byte {
+	files := make(map[string][]byte)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		testdata := strings.Contains("/"+name, "/testdata/")
+		if !testdata && filepath.Ext(name) != ".go" {
+			return nil
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if testdata || bytes.HasPrefix(b, []byte("// Code generated by gen.go")) {
+			files[name] = b
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
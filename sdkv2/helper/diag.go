@@ -0,0 +1,48 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// Diagnostics converts err into the diagnostics returned by the context aware
+// functions of a resource. Errors wrapping several errors, such as
+// expand.Errors, result in one diagnostic each, and errors concerning a single
+// attribute, such as *SchemaError, are attached to its path.
+//
+// A nil err results in no diagnostics.
+func Diagnostics(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	if u, ok := err.(interface{ Unwrap() []error }); ok {
+		var diags diag.Diagnostics
+		for _, err := range u.Unwrap() {
+			diags = append(diags, Diagnostics(err)...)
+		}
+		return diags
+	}
+	var se *SchemaError
+	if errors.As(err, &se) {
+		return diag.Diagnostics{PathDiagnostic(se.Path, err)}
+	}
+	return diag.FromErr(err)
+}
+
+// PathDiagnostic returns an error diagnostic for err, attached to the attribute
+// at path p. Paths through a set are attached to the set, as cty can't address
+// its elements by hash, and the detail of the diagnostic holds the full path.
+func PathDiagnostic(p Path, err error) diag.Diagnostic {
+	path, ok := p.Cty()
+	d := diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       err.Error(),
+		AttributePath: path,
+	}
+	if !ok {
+		d.Detail = fmt.Sprintf("The attribute path was truncated at the set holding %q.", p.String())
+	}
+	return d
+}
@@ -0,0 +1,45 @@
+package helper
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/internal/testing/expect"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type multiError []error
+
+func (e multiError) Error() string   { return "multiple errors" }
+func (e multiError) Unwrap() []error { return e }
+
+func TestDiagnostics(t *testing.T) {
+	expect.Expect(t, Diagnostics(nil), diag.Diagnostics(nil))
+
+	d := NewMapData(map[string]*schema.Schema{
+		"nested": {Type: schema.TypeList, Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{"size": {Type: schema.TypeInt}},
+		}},
+	})
+	d.Set("nested.0.size", "large")
+	err := d.Err()
+
+	diags := Diagnostics(multiError{errors.New("foo"), err})
+	expect.Expect(t, len(diags), 2)
+	expect.Expect(t, diags[0].Summary, "foo")
+	expect.Expect(t, diags[0].AttributePath, cty.Path(nil))
+	expect.Expect(t, diags[1].Summary, err.Error())
+	expect.Expect(t, diags[1].AttributePath, cty.GetAttrPath("nested").IndexInt(0).GetAttr("size"))
+	expect.Expect(t, diags.HasError(), true)
+}
+
+func TestPathDiagnosticSet(t *testing.T) {
+	d := PathDiagnostic(Path{Key("tags"), SetHash(42), Key("name")}, errors.New("foo"))
+	expect.Expect(t, d.AttributePath, cty.GetAttrPath("tags"))
+	expect.Expect(t, d.Detail, `The attribute path was truncated at the set holding "tags.42.name".`)
+
+	d = PathDiagnostic(Path{Key("name")}, errors.New("foo"))
+	expect.Expect(t, d.Detail, "")
+}
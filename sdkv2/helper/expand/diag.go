@@ -0,0 +1,71 @@
+package expand
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/helper"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Diagnostics converts err into diagnostics, like helper.Diagnostics, attaching
+// each *TypeError to the path of the attribute which couldn't be converted.
+func Diagnostics(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	if u, ok := err.(interface{ Unwrap() []error }); ok {
+		var diags diag.Diagnostics
+		for _, err := range u.Unwrap() {
+			diags = append(diags, Diagnostics(err)...)
+		}
+		return diags
+	}
+	var te *TypeError
+	if errors.As(err, &te) {
+		return diag.Diagnostics{helper.PathDiagnostic(te.Path, err)}
+	}
+	return helper.Diagnostics(err)
+}
+
+// StructDiags behaves like Struct, except that errors are returned as
+// diagnostics.
+func StructDiags(d helper.ResourceData, dst interface{}) diag.Diagnostics {
+	return Diagnostics(Struct(d, dst))
+}
+
+// CollectDiags calls fn with d wrapped by Collect, and returns the errors it
+// collected as diagnostics, one per error.
+//
+//	var api ec2.Instance
+//	diags := CollectDiags(d, func(d helper.ResourceData) {
+//		api.InstanceType = StringPtr(d, "instance_type")
+//	})
+func CollectDiags(d helper.ResourceData, fn func(helper.ResourceData)) diag.Diagnostics {
+	d, errs := Collect(d)
+	fn(d)
+	return Diagnostics(errs.Err())
+}
+
+// ContextFunc is a context aware function of a resource, such as the one
+// creating it, operating on helper.ResourceData and returning an error.
+type ContextFunc func(ctx context.Context, d helper.ResourceData, meta interface{}) error
+
+// WithDiagnostics adapts fn to the context aware functions of a
+// schema.Resource, such as CreateContext or ReadContext, converting the error
+// it returns into diagnostics using Diagnostics.
+//
+//	r := &schema.Resource{
+//		CreateContext: WithDiagnostics(func(ctx context.Context, d helper.ResourceData, meta interface{}) error {
+//			d, errs := Collect(d)
+//			api := ec2.RunInstancesInput{InstanceType: StringPtr(d, "instance_type")}
+//			...
+//			return errs.Err()
+//		}),
+//	}
+func WithDiagnostics(fn ContextFunc) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return Diagnostics(fn(ctx, d, meta))
+	}
+}
@@ -0,0 +1,64 @@
+package expand
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/helper"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDiagnostics(t *testing.T) {
+	Expect(t, Diagnostics(nil), diag.Diagnostics(nil))
+
+	diags := Diagnostics(errors.New("foo"))
+	Expect(t, len(diags), 1)
+	Expect(t, diags[0].Summary, "foo")
+}
+
+func TestCollectDiags(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, s, rawErrors)
+
+	diags := CollectDiags(d, func(d helper.ResourceData) {
+		String(d, "int")
+		List(d, "list").Elem(func(d helper.ResourceData) {
+			BoolPtr(d, "foo")
+		})
+	})
+	Expect(t, len(diags), 2)
+	Expect(t, diags[0].Summary, `expand: cannot convert int to string at "int"`)
+	Expect(t, diags[0].AttributePath, cty.GetAttrPath("int"))
+	Expect(t, diags[1].AttributePath, cty.GetAttrPath("list").IndexInt(0).GetAttr("foo"))
+
+	diags = CollectDiags(d, func(d helper.ResourceData) {
+		String(d, "string")
+	})
+	Expect(t, len(diags), 0)
+}
+
+type contextKey struct{}
+
+func TestWithDiagnostics(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, s, rawErrors)
+	ctx := context.WithValue(context.Background(), contextKey{}, "bar")
+
+	r := &schema.Resource{
+		ReadContext: WithDiagnostics(func(ctx context.Context, d helper.ResourceData, meta interface{}) error {
+			Expect(t, ctx.Value(contextKey{}), "bar")
+			Expect(t, meta, "meta")
+			_, err := StringE(d, "int")
+			return err
+		}),
+	}
+	diags := r.ReadContext(ctx, d, "meta")
+	Expect(t, len(diags), 1)
+	Expect(t, diags[0].AttributePath, cty.GetAttrPath("int"))
+
+	diags = WithDiagnostics(func(context.Context, helper.ResourceData, interface{}) error {
+		return nil
+	})(ctx, d, nil)
+	Expect(t, diags, diag.Diagnostics(nil))
+}
@@ -0,0 +1,33 @@
+package flatten
+
+import (
+	"errors"
+
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/helper"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// IntoDiags behaves like Into, except that errors are returned as diagnostics.
+func IntoDiags(d helper.ResourceData, v interface{}) diag.Diagnostics {
+	return helper.Diagnostics(Into(d, v))
+}
+
+// SetDiags flattens f using FlattenSchema against the schema of the nested
+// block key of d, and sets the result. Values not conforming to the schema are
+// reported as diagnostics attached to their path within key.
+func SetDiags(d helper.ResourceData, key string, m map[string]*schema.Schema, f Flattener) diag.Diagnostics {
+	v, err := FlattenSchema(m, f)
+	if err != nil {
+		var se *helper.SchemaError
+		if errors.As(err, &se) {
+			p := append(helper.Path{helper.Key(key), helper.Index(0)}, se.Path...)
+			return diag.Diagnostics{helper.PathDiagnostic(p, err)}
+		}
+		return helper.Diagnostics(err)
+	}
+	if err := d.Set(key, v); err != nil {
+		return diag.Diagnostics{helper.PathDiagnostic(helper.Path{helper.Key(key)}, err)}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package flatten
+
+import (
+	"testing"
+
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/helper"
+	"github.com/alexkappa/terraform-plugin-helper/sdkv2/internal/testing/expect"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestSetDiags(t *testing.T) {
+	nested := map[string]*schema.Schema{
+		"name": {Type: schema.TypeString},
+		"size": {Type: schema.TypeInt},
+	}
+	s := map[string]*schema.Schema{
+		"nested": {Type: schema.TypeList, Elem: &schema.Resource{Schema: nested}},
+	}
+
+	d := schema.TestResourceDataRaw(t, s, nil)
+	diags := SetDiags(d, "nested", nested, FlattenerFunc(func(d helper.ResourceData) {
+		d.Set("name", "foo")
+		d.Set("size", 3)
+	}))
+	expect.Expect(t, len(diags), 0)
+	expect.Expect(t, d.Get("nested.0.name"), "foo")
+	expect.Expect(t, d.Get("nested.0.size"), 3)
+
+	diags = SetDiags(d, "nested", nested, FlattenerFunc(func(d helper.ResourceData) {
+		d.Set("size", "large")
+	}))
+	expect.Expect(t, len(diags), 1)
+	expect.Expect(t, diags[0].AttributePath, cty.GetAttrPath("nested").IndexInt(0).GetAttr("size"))
+}
+
+func TestIntoDiags(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"name": {Type: schema.TypeString},
+	}, nil)
+	diags := IntoDiags(d, 42)
+	expect.Expect(t, len(diags), 1)
+	expect.Expect(t, diags.HasError(), true)
+}
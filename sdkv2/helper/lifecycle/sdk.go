@@ -0,0 +1,79 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// The functions below call the functions of a resource, whose signatures differ
+// between versions of the SDK.
+
+func plan(r *schema.Resource, s *terraform.InstanceState, c *terraform.ResourceConfig, meta interface{}) (*terraform.InstanceDiff, error) {
+	return r.Diff(context.Background(), s, c, meta)
+}
+
+func apply(r *schema.Resource, d *terraform.InstanceDiff, meta interface{}) (*terraform.InstanceState, error) {
+	s, diags := r.Apply(context.Background(), nil, d, meta)
+	return s, diagsErr(diags)
+}
+
+func refresh(r *schema.Resource, s *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	id := s.ID
+	s, diags := r.RefreshWithoutUpgrade(context.Background(), s, meta)
+	if err := diagsErr(diags); err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, fmt.Errorf("resource %q no longer exists", id)
+	}
+	return s, nil
+}
+
+func importState(r *schema.Resource, id string, meta interface{}) (*terraform.InstanceState, error) {
+	d := r.Data(nil)
+	d.SetId(id)
+
+	fn := r.Importer.StateContext
+	switch {
+	case fn != nil:
+	case r.Importer.State != nil:
+		state := r.Importer.State
+		fn = func(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			return state(d, meta)
+		}
+	default:
+		fn = schema.ImportStatePassthroughContext
+	}
+	data, err := fn(context.Background(), d, meta)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != 1 {
+		return nil, fmt.Errorf("expected a single resource, got %d", len(data))
+	}
+	s := data[0].State()
+	if s == nil {
+		return nil, fmt.Errorf("imported resource has no ID")
+	}
+	return refresh(r, s, meta)
+}
+
+// diagsErr returns the error diagnostics of diags as an error.
+func diagsErr(diags diag.Diagnostics) error {
+	var s []string
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			s = append(s, d.Summary)
+		}
+	}
+	if len(s) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(s, "; "))
+}
@@ -0,0 +1,14 @@
+package resourcetest
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// plan returns the diff of config against state. It is kept apart as its
+// signature differs between versions of the SDK.
+func plan(sm schema.InternalMap, state *terraform.InstanceState, config *terraform.ResourceConfig) (*terraform.InstanceDiff, error) {
+	return sm.Diff(context.Background(), state, config, nil, nil, true)
+}
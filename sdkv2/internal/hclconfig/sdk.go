@@ -0,0 +1,73 @@
+package hclconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2/hcldec"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// decoderSpec returns the spec of the core configuration schema of a resource
+// with schema s. It is kept apart as the core schema isn't exported by every
+// version of the SDK.
+//
+// This version of the SDK only exposes the core schema through the schema of
+// a provider, so the spec is built from the block of a provider serving the
+// resource alone, in the same way as the SDK builds it.
+func decoderSpec(s map[string]*schema.Schema) (hcldec.Spec, error) {
+	p := schema.NewGRPCProviderServer(&schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{"resource": {Schema: s}},
+	})
+	resp, err := p.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("hclconfig: unable to get the core schema: %s", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			return nil, fmt.Errorf("hclconfig: unable to get the core schema: %s", d.Summary)
+		}
+	}
+	return blockSpec(resp.ResourceSchemas["resource"].Block)
+}
+
+// blockSpec returns the spec decoding the body of block b. Like the SDK, the
+// number of nested blocks is only checked for blocks which require more than
+// one, as a dynamic block may expand to any number of them.
+func blockSpec(b *tfprotov5.SchemaBlock) (hcldec.Spec, error) {
+	spec := hcldec.ObjectSpec{}
+	for _, a := range b.Attributes {
+		buf, err := json.Marshal(a.Type)
+		if err != nil {
+			return nil, fmt.Errorf("hclconfig: unable to convert the type of %q: %s", a.Name, err)
+		}
+		t, err := ctyjson.UnmarshalType(buf)
+		if err != nil {
+			return nil, fmt.Errorf("hclconfig: unable to convert the type of %q: %s", a.Name, err)
+		}
+		spec[a.Name] = &hcldec.AttrSpec{Name: a.Name, Type: t, Required: a.Required}
+	}
+	for _, nb := range b.BlockTypes {
+		if _, ok := spec[nb.TypeName]; ok {
+			continue
+		}
+		nested, err := blockSpec(nb.Block)
+		if err != nil {
+			return nil, err
+		}
+		minItems := 0
+		if nb.MinItems > 1 {
+			minItems = 1
+		}
+		switch nb.Nesting {
+		case tfprotov5.SchemaNestedBlockNestingModeList:
+			spec[nb.TypeName] = &hcldec.BlockListSpec{TypeName: nb.TypeName, Nested: nested, MinItems: minItems}
+		case tfprotov5.SchemaNestedBlockNestingModeSet:
+			spec[nb.TypeName] = &hcldec.BlockSetSpec{TypeName: nb.TypeName, Nested: nested, MinItems: minItems}
+		}
+	}
+	return spec, nil
+}